@@ -0,0 +1,18 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package autograd implements automatic differentiation of
+// computations over floating-point Tensors.
+//
+// Reverse-mode differentiation is provided by Variables, which record
+// the operations they take part in, and whose gradients are computed
+// by calling Backward on the result of a computation. Operations
+// performed within a NoGrad scope aren't recorded, nor are those on a
+// Variable returned by its NoGrad method and on the results computed
+// from it, which leaves computations in other goroutines unaffected.
+//
+// Forward-mode differentiation is provided by DualTensors, which carry
+// the directional derivative of their values alongside them, and from
+// which JVP and Jacobian are built.
+package autograd
@@ -0,0 +1,188 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// A DualTensor is a Tensor of dual numbers, made of a primal Tensor
+// and of a tangent Tensor of the same shape holding the directional
+// derivative of the primal. Operations over DualTensors propagate
+// the tangent alongside the primal, which implements forward-mode
+// differentiation.
+type DualTensor[T nune.Float] struct {
+	Primal  *tensor.Tensor[T]
+	Tangent *tensor.Tensor[T]
+}
+
+// NewDual returns a DualTensor made of the given primal and tangent.
+// A nil tangent is replaced by zeros, making the primal a constant.
+func NewDual[T nune.Float](primal, tangent *tensor.Tensor[T]) *DualTensor[T] {
+	if tangent == nil {
+		tangent = full(primal.Shape(), T(0))
+	} else if !slice.Equal(primal.Shape(), tangent.Shape()) {
+		panic(ErrTangent)
+	}
+
+	return &DualTensor[T]{
+		Primal:  primal,
+		Tangent: tangent,
+	}
+}
+
+// Add returns the element-wise sum of the two DualTensors,
+// broadcasted together.
+func (d *DualTensor[T]) Add(other *DualTensor[T]) *DualTensor[T] {
+	return NewDual(
+		tensor.Add(d.Primal, other.Primal),
+		tensor.Add(d.Tangent, other.Tangent),
+	)
+}
+
+// Sub returns the element-wise difference of the two DualTensors,
+// broadcasted together.
+func (d *DualTensor[T]) Sub(other *DualTensor[T]) *DualTensor[T] {
+	return NewDual(
+		tensor.Sub(d.Primal, other.Primal),
+		tensor.Sub(d.Tangent, other.Tangent),
+	)
+}
+
+// Mul returns the element-wise product of the two DualTensors,
+// broadcasted together.
+func (d *DualTensor[T]) Mul(other *DualTensor[T]) *DualTensor[T] {
+	return NewDual(
+		tensor.Mul(d.Primal, other.Primal),
+		tensor.Add(
+			tensor.Mul(d.Tangent, other.Primal),
+			tensor.Mul(d.Primal, other.Tangent),
+		),
+	)
+}
+
+// Div returns the element-wise quotient of the two DualTensors,
+// broadcasted together.
+func (d *DualTensor[T]) Div(other *DualTensor[T]) *DualTensor[T] {
+	q := tensor.Div(d.Primal, other.Primal)
+
+	return NewDual(q, tensor.Div(
+		tensor.Sub(d.Tangent, tensor.Mul(q, other.Tangent)),
+		other.Primal,
+	))
+}
+
+// PwiseOp returns the result of the pointwise function f over the
+// DualTensor, given its derivative df.
+func (d *DualTensor[T]) PwiseOp(f, df func(T) T) *DualTensor[T] {
+	return NewDual(
+		tensor.PwiseOp(d.Primal, f),
+		tensor.Mul(d.Tangent, tensor.PwiseOp(d.Primal, df)),
+	)
+}
+
+// Abs returns the absolute value of each element of the DualTensor.
+func (d *DualTensor[T]) Abs() *DualTensor[T] {
+	return d.PwiseOp(abs[T], dabs[T])
+}
+
+// Sin returns the sine value of each element of the DualTensor.
+func (d *DualTensor[T]) Sin() *DualTensor[T] {
+	return d.PwiseOp(sin[T], cos[T])
+}
+
+// Cos returns the cosine value of each element of the DualTensor.
+func (d *DualTensor[T]) Cos() *DualTensor[T] {
+	return d.PwiseOp(cos[T], dcos[T])
+}
+
+// Tan returns the tan value of each element of the DualTensor.
+func (d *DualTensor[T]) Tan() *DualTensor[T] {
+	return d.PwiseOp(tan[T], dtan[T])
+}
+
+// Log returns the natural log value of each element of the DualTensor.
+func (d *DualTensor[T]) Log() *DualTensor[T] {
+	return d.PwiseOp(log[T], dlog[T])
+}
+
+// Log2 returns the binary log value of each element of the DualTensor.
+func (d *DualTensor[T]) Log2() *DualTensor[T] {
+	return d.PwiseOp(log2[T], dlog2[T])
+}
+
+// Log10 returns the decimal log value of each element of the DualTensor.
+func (d *DualTensor[T]) Log10() *DualTensor[T] {
+	return d.PwiseOp(log10[T], dlog10[T])
+}
+
+// Exp returns the base-e exponential value of each element of the DualTensor.
+func (d *DualTensor[T]) Exp() *DualTensor[T] {
+	return d.PwiseOp(exp[T], exp[T])
+}
+
+// Pow returns the base-value exponential of p of each element of the DualTensor.
+func (d *DualTensor[T]) Pow(p T) *DualTensor[T] {
+	return d.PwiseOp(powOf(p), dpowOf(p))
+}
+
+// Sqrt returns the square root value of each element of the DualTensor.
+func (d *DualTensor[T]) Sqrt() *DualTensor[T] {
+	return d.PwiseOp(sqrt[T], dsqrt[T])
+}
+
+// Round returns the nearest integer value of each element of the
+// DualTensor, whose derivative is null.
+func (d *DualTensor[T]) Round() *DualTensor[T] {
+	return d.PwiseOp(round[T], zero[T])
+}
+
+// Floor returns the nearest lesser integer value of each element of the
+// DualTensor, whose derivative is null.
+func (d *DualTensor[T]) Floor() *DualTensor[T] {
+	return d.PwiseOp(floor[T], zero[T])
+}
+
+// Ceil returns the nearest greater integer value of each element of the
+// DualTensor, whose derivative is null.
+func (d *DualTensor[T]) Ceil() *DualTensor[T] {
+	return d.PwiseOp(ceil[T], zero[T])
+}
+
+// JVP evaluates f at x and returns its value along with the product of
+// its Jacobian at x with the vector v, which must share the shape of x.
+func JVP[T nune.Float](f func(*DualTensor[T]) *DualTensor[T], x, v *tensor.Tensor[T]) (*tensor.Tensor[T], *tensor.Tensor[T]) {
+	y := f(NewDual(x, v))
+	return y.Primal, y.Tangent
+}
+
+// Jacobian returns the Jacobian of f at x, whose shape is the shape of
+// f's result followed by the shape of x. It is assembled column by column,
+// evaluating f once along each element of x.
+func Jacobian[T nune.Float](f func(*DualTensor[T]) *DualTensor[T], x *tensor.Tensor[T]) *tensor.Tensor[T] {
+	n := x.Numel()
+
+	var data []T
+	var shape []int
+
+	for j := 0; j < n; j++ {
+		basis := make([]T, n)
+		basis[j] = 1
+
+		y, jv := JVP(f, x, tensor.FromBuffer(basis, x.Shape()...))
+		if j == 0 {
+			shape = append(slice.Copy(y.Shape()), x.Shape()...)
+			data = make([]T, y.Numel()*n)
+		}
+
+		for i, d := range jv.Ravel() {
+			data[i*n+j] = d
+		}
+	}
+
+	return tensor.FromBuffer(data, shape...)
+}
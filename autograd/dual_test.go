@@ -0,0 +1,58 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+func TestJacobian(t *testing.T) {
+	c := in(1, 2, 3)
+
+	tests := []struct {
+		name string
+		f    func(*DualTensor[float64]) *DualTensor[float64]
+		x    *tensor.Tensor[float64]
+	}{
+		{"pwise", func(d *DualTensor[float64]) *DualTensor[float64] {
+			return d.Sin().Mul(d.Exp()).Add(d.Pow(3).Sqrt())
+		}, in(0.5, 2, 3)},
+		{"logs", func(d *DualTensor[float64]) *DualTensor[float64] {
+			return d.Log().Sub(d.Log2()).Div(d.Log10().Add(d.Cos()))
+		}, in(1.5, 3, 2, 2)},
+		{"constant", func(d *DualTensor[float64]) *DualTensor[float64] {
+			return d.Mul(NewDual(c, nil)).Tan().Abs()
+		}, in(-0.5, 0.5, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jac := Jacobian(tt.f, tt.x)
+
+			x := tt.x.Ravel()
+			n := len(x)
+			got := jac.Ravel()
+
+			for j := range x {
+				at := func(d float64) []float64 {
+					data := append([]float64(nil), x...)
+					data[j] += d
+					return tt.f(NewDual(tensor.FromBuffer(data, tt.x.Shape()...), nil)).Primal.Ravel()
+				}
+
+				fp, fm := at(h), at(-h)
+				for i := range fp {
+					want := (fp[i] - fm[i]) / (2 * h)
+					if math.Abs(got[i*n+j]-want) > 1e-5*math.Max(1, math.Abs(want)) {
+						t.Fatalf("∂f%d/∂x%d: got %v, want %v", i, j, got[i*n+j], want)
+					}
+				}
+			}
+		})
+	}
+}
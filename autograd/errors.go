@@ -0,0 +1,23 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import "errors"
+
+// List of errors.
+var (
+	// ErrBackward occurs when Backward is called without a gradient on
+	// a Variable which isn't a scalar, or with a gradient whose shape
+	// doesn't match the Variable's.
+	ErrBackward = errors.New("nune/autograd: Backward requires a gradient of the Variable's shape for non-scalar Variables")
+
+	// ErrGradients occurs when Backward receives
+	// more than one gradient.
+	ErrGradients = errors.New("nune/autograd: Backward received more than one gradient")
+
+	// ErrTangent occurs when a DualTensor's tangent
+	// doesn't share the shape of its primal.
+	ErrTangent = errors.New("nune/autograd: the tangent's shape must match the primal's shape")
+)
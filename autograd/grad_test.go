@@ -0,0 +1,137 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+// h is the step of the central differences.
+const h = 1e-6
+
+// numGrad returns the gradient of the scalar function f with respect to
+// its i-th input at xs, approximated through central differences.
+func numGrad(f func(...*Variable[float64]) *Variable[float64], xs []*tensor.Tensor[float64], i int) []float64 {
+	eval := func(data []float64) float64 {
+		vs := make([]*Variable[float64], len(xs))
+		for j, x := range xs {
+			vs[j] = New(x, false)
+		}
+		vs[i] = New(tensor.FromBuffer(data, xs[i].Shape()...), false)
+
+		return f(vs...).Value.Ravel()[0]
+	}
+
+	x := xs[i].Ravel()
+	grad := make([]float64, len(x))
+
+	for k := range x {
+		data := append([]float64(nil), x...)
+
+		data[k] = x[k] + h
+		fp := eval(data)
+		data[k] = x[k] - h
+		fm := eval(data)
+
+		grad[k] = (fp - fm) / (2 * h)
+	}
+
+	return grad
+}
+
+// in returns a Tensor of the given shape holding distinct
+// elements in [lo, hi), away from the kinks of Abs, Round, etc.
+func in(lo, hi float64, shape ...int) *tensor.Tensor[float64] {
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = lo + (hi-lo)*(float64(i)+0.37)/float64(n)
+	}
+
+	return tensor.FromBuffer(data, shape...)
+}
+
+func TestGradients(t *testing.T) {
+	type fn = func(...*Variable[float64]) *Variable[float64]
+
+	tests := []struct {
+		name string
+		f    fn
+		xs   []*tensor.Tensor[float64]
+	}{
+		{"add broadcasted", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Mul(Add(v[0], v[1]), v[0]))
+		}, []*tensor.Tensor[float64]{in(-1, 1, 2, 3), in(0, 2, 3)}},
+		{"sub broadcasted", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Mul(Sub(v[0], v[1]), v[1]))
+		}, []*tensor.Tensor[float64]{in(-1, 1, 2, 3), in(0, 2, 2, 1)}},
+		{"div", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Div(v[0], v[1]))
+		}, []*tensor.Tensor[float64]{in(-1, 1, 2, 3), in(1, 3, 3)}},
+		{"sin cos tan", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Add(Mul(Sin(v[0]), Cos(v[0])), Tan(v[0])))
+		}, []*tensor.Tensor[float64]{in(-1, 1, 4)}},
+		{"exp log", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Add(Add(Log(v[0]), Log2(v[0])), Mul(Log10(v[0]), Exp(v[0]))))
+		}, []*tensor.Tensor[float64]{in(0.5, 3, 5)}},
+		{"pow sqrt abs", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Add(Pow(Abs(v[0]), 3), Sqrt(Abs(v[0]))))
+		}, []*tensor.Tensor[float64]{in(-2, 2, 6)}},
+		{"round floor ceil", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Mul(Add(Round(v[0]), Add(Floor(v[0]), Ceil(v[0]))), v[0]))
+		}, []*tensor.Tensor[float64]{in(-2, 2, 6)}},
+		{"matmul", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Sin(MatMul(v[0], v[1])))
+		}, []*tensor.Tensor[float64]{in(-1, 1, 2, 3), in(-1, 2, 3, 4)}},
+		{"batched matmul", func(v ...*Variable[float64]) *Variable[float64] {
+			return Sum(Exp(MatMul(v[0], v[1])))
+		}, []*tensor.Tensor[float64]{in(-1, 1, 2, 2, 3), in(-1, 1, 3, 2)}},
+		{"reshape permute transpose", func(v ...*Variable[float64]) *Variable[float64] {
+			p := Permute(Reshape(v[0], 2, 3, 2), 1, 2, 0)
+			return Sum(Mul(Transpose(p), v[1]))
+		}, []*tensor.Tensor[float64]{in(-1, 1, 12), in(0, 1, 2, 2, 3)}},
+		{"mean prod", func(v ...*Variable[float64]) *Variable[float64] {
+			return Add(Mean(Sin(v[0])), Prod(v[0]))
+		}, []*tensor.Tensor[float64]{in(0.5, 2, 2, 3)}},
+		{"min max", func(v ...*Variable[float64]) *Variable[float64] {
+			return Add(Min(Sin(v[0])), Max(Cos(v[0])))
+		}, []*tensor.Tensor[float64]{in(-1.5, 1.5, 7)}},
+		{"axis reductions", func(v ...*Variable[float64]) *Variable[float64] {
+			s := Mul(SumAxis(v[0], []int{0}, false), MeanAxis(v[0], []int{0}, false))
+			p := Mul(ProdAxis(v[0], []int{1}, true), v[0])
+			m := Add(MinAxis(v[0], []int{1}, true), MaxAxis(v[0], []int{0}, true))
+			return Add(Add(Sum(s), Sum(p)), Sum(m))
+		}, []*tensor.Tensor[float64]{in(0.5, 2, 3, 4)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := make([]*Variable[float64], len(tt.xs))
+			for i, x := range tt.xs {
+				vs[i] = New(x, true)
+			}
+
+			tt.f(vs...).Backward()
+
+			for i, v := range vs {
+				want := numGrad(tt.f, tt.xs, i)
+				got := v.Grad.Ravel()
+
+				for k := range want {
+					if math.Abs(got[k]-want[k]) > 1e-5*math.Max(1, math.Abs(want[k])) {
+						t.Fatalf("input %d: got gradient %v, want %v", i, got, want)
+					}
+				}
+			}
+		})
+	}
+}
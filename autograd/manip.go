@@ -0,0 +1,44 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// Reshape returns the Variable with the given shape.
+func Reshape[T nune.Float](v *Variable[T], shape ...int) *Variable[T] {
+	return record(v.Value.Reshape(shape...), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			g.Reshape(v.Value.Shape()...),
+		}
+	}, v)
+}
+
+// Permute returns the Variable with its axes reordered,
+// following the semantics of the Tensor's Permute method.
+func Permute[T nune.Float](v *Variable[T], axes ...int) *Variable[T] {
+	return record(v.Value.Permute(axes...), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		inv := make([]int, len(axes))
+		for i, a := range axes {
+			inv[a] = i
+		}
+
+		return []*tensor.Tensor[T]{
+			g.Permute(inv...),
+		}
+	}, v)
+}
+
+// Transpose returns the Variable with the order of its axes reversed.
+func Transpose[T nune.Float](v *Variable[T]) *Variable[T] {
+	axes := make([]int, v.Value.Rank())
+	for i := range axes {
+		axes[i] = len(axes) - 1 - i
+	}
+
+	return Permute(v, axes...)
+}
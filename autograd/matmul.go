@@ -0,0 +1,48 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// MatMul returns the matrix product of the two Variables,
+// following the semantics of tensor.MatMul.
+func MatMul[T nune.Float](a, b *Variable[T]) *Variable[T] {
+	return record(tensor.MatMul(a.Value, b.Value), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		// promote vectors to matrices, as tensor.MatMul does
+		x, y := a.Value, b.Value
+		if x.Rank() == 1 {
+			x = x.Reshape(1, x.Size())
+		}
+		if y.Rank() == 1 {
+			y = y.Reshape(y.Size(), 1)
+		}
+
+		shape := tensor.BroadcastShapes(x.Shape()[:x.Rank()-2], y.Shape()[:y.Rank()-2])
+		shape = append(shape, x.Size(x.Rank()-2), y.Size(y.Rank()-1))
+		g = g.Reshape(shape...)
+
+		ga := tensor.MatMul(g, transpose(y))
+		gb := tensor.MatMul(transpose(x), g)
+
+		return []*tensor.Tensor[T]{
+			unbroadcast(ga, x.Shape()).Reshape(a.Value.Shape()...),
+			unbroadcast(gb, y.Shape()).Reshape(b.Value.Shape()...),
+		}
+	}, a, b)
+}
+
+// transpose returns a view over the Tensor with its last two axes swapped.
+func transpose[T nune.Float](t *tensor.Tensor[T]) *tensor.Tensor[T] {
+	axes := make([]int, t.Rank())
+	for i := range axes {
+		axes[i] = i
+	}
+	axes[len(axes)-2], axes[len(axes)-1] = axes[len(axes)-1], axes[len(axes)-2]
+
+	return t.Permute(axes...)
+}
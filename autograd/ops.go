@@ -0,0 +1,64 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// Add returns the element-wise sum of the two Variables,
+// broadcasted together.
+func Add[T nune.Float](a, b *Variable[T]) *Variable[T] {
+	return record(tensor.Add(a.Value, b.Value), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			unbroadcast(g, a.Value.Shape()),
+			unbroadcast(g, b.Value.Shape()),
+		}
+	}, a, b)
+}
+
+// Sub returns the element-wise difference of the two Variables,
+// broadcasted together.
+func Sub[T nune.Float](a, b *Variable[T]) *Variable[T] {
+	return record(tensor.Sub(a.Value, b.Value), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			unbroadcast(g, a.Value.Shape()),
+			unbroadcast(neg(g), b.Value.Shape()),
+		}
+	}, a, b)
+}
+
+// Mul returns the element-wise product of the two Variables,
+// broadcasted together.
+func Mul[T nune.Float](a, b *Variable[T]) *Variable[T] {
+	return record(tensor.Mul(a.Value, b.Value), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			unbroadcast(tensor.Mul(g, b.Value), a.Value.Shape()),
+			unbroadcast(tensor.Mul(g, a.Value), b.Value.Shape()),
+		}
+	}, a, b)
+}
+
+// Div returns the element-wise quotient of the two Variables,
+// broadcasted together.
+func Div[T nune.Float](a, b *Variable[T]) *Variable[T] {
+	return record(tensor.Div(a.Value, b.Value), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		ga := tensor.Div(g, b.Value)
+		gb := neg(tensor.Div(tensor.Mul(ga, a.Value), b.Value))
+
+		return []*tensor.Tensor[T]{
+			unbroadcast(ga, a.Value.Shape()),
+			unbroadcast(gb, b.Value.Shape()),
+		}
+	}, a, b)
+}
+
+// neg returns the element-wise negation of the Tensor.
+func neg[T nune.Float](t *tensor.Tensor[T]) *tensor.Tensor[T] {
+	return tensor.PwiseOp(t, func(x T) T {
+		return -x
+	})
+}
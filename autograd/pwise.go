@@ -0,0 +1,194 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// PwiseOp returns the result of the pointwise function f over the
+// Variable, given its derivative df.
+func PwiseOp[T nune.Float](v *Variable[T], f, df func(T) T) *Variable[T] {
+	return record(tensor.PwiseOp(v.Value, f), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			tensor.Mul(g, tensor.PwiseOp(v.Value, df)),
+		}
+	}, v)
+}
+
+// Abs returns the absolute value of each element of the Variable.
+func Abs[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, abs[T], dabs[T])
+}
+
+// Sin returns the sine value of each element of the Variable.
+func Sin[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, sin[T], cos[T])
+}
+
+// Cos returns the cosine value of each element of the Variable.
+func Cos[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, cos[T], dcos[T])
+}
+
+// Tan returns the tan value of each element of the Variable.
+func Tan[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, tan[T], dtan[T])
+}
+
+// Log returns the natural log value of each element of the Variable.
+func Log[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, log[T], dlog[T])
+}
+
+// Log2 returns the binary log value of each element of the Variable.
+func Log2[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, log2[T], dlog2[T])
+}
+
+// Log10 returns the decimal log value of each element of the Variable.
+func Log10[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, log10[T], dlog10[T])
+}
+
+// Exp returns the base-e exponential value of each element of the Variable.
+func Exp[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, exp[T], exp[T])
+}
+
+// Pow returns the base-value exponential of p of each element of the Variable.
+func Pow[T nune.Float](v *Variable[T], p T) *Variable[T] {
+	return PwiseOp(v, powOf(p), dpowOf(p))
+}
+
+// Sqrt returns the square root value of each element of the Variable.
+func Sqrt[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, sqrt[T], dsqrt[T])
+}
+
+// Round returns the nearest integer value of each element of the Variable,
+// whose gradient is null.
+func Round[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, round[T], zero[T])
+}
+
+// Floor returns the nearest lesser integer value of each element of the
+// Variable, whose gradient is null.
+func Floor[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, floor[T], zero[T])
+}
+
+// Ceil returns the nearest greater integer value of each element of the
+// Variable, whose gradient is null.
+func Ceil[T nune.Float](v *Variable[T]) *Variable[T] {
+	return PwiseOp(v, ceil[T], zero[T])
+}
+
+func abs[T nune.Float](x T) T {
+	return T(math.Abs(float64(x)))
+}
+
+func sin[T nune.Float](x T) T {
+	return T(math.Sin(float64(x)))
+}
+
+func cos[T nune.Float](x T) T {
+	return T(math.Cos(float64(x)))
+}
+
+func tan[T nune.Float](x T) T {
+	return T(math.Tan(float64(x)))
+}
+
+func log[T nune.Float](x T) T {
+	return T(math.Log(float64(x)))
+}
+
+func log2[T nune.Float](x T) T {
+	return T(math.Log2(float64(x)))
+}
+
+func log10[T nune.Float](x T) T {
+	return T(math.Log10(float64(x)))
+}
+
+func exp[T nune.Float](x T) T {
+	return T(math.Exp(float64(x)))
+}
+
+func pow[T nune.Float](x, p T) T {
+	return T(math.Pow(float64(x), float64(p)))
+}
+
+func powOf[T nune.Float](p T) func(T) T {
+	return func(x T) T {
+		return pow(x, p)
+	}
+}
+
+func sqrt[T nune.Float](x T) T {
+	return T(math.Sqrt(float64(x)))
+}
+
+func round[T nune.Float](x T) T {
+	return T(math.Round(float64(x)))
+}
+
+func floor[T nune.Float](x T) T {
+	return T(math.Floor(float64(x)))
+}
+
+func ceil[T nune.Float](x T) T {
+	return T(math.Ceil(float64(x)))
+}
+
+func zero[T nune.Float](x T) T {
+	return 0
+}
+
+func dabs[T nune.Float](x T) T {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func dcos[T nune.Float](x T) T {
+	return -sin(x)
+}
+
+func dtan[T nune.Float](x T) T {
+	c := cos(x)
+	return 1 / (c * c)
+}
+
+func dlog[T nune.Float](x T) T {
+	return 1 / x
+}
+
+func dlog2[T nune.Float](x T) T {
+	return 1 / (x * math.Ln2)
+}
+
+func dlog10[T nune.Float](x T) T {
+	return 1 / (x * math.Ln10)
+}
+
+func dpowOf[T nune.Float](p T) func(T) T {
+	return func(x T) T {
+		return p * pow(x, p-1)
+	}
+}
+
+func dsqrt[T nune.Float](x T) T {
+	return 1 / (2 * sqrt(x))
+}
@@ -0,0 +1,187 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// Sum returns the sum of all elements of the Variable.
+func Sum[T nune.Float](v *Variable[T]) *Variable[T] {
+	return SumAxis(v, nil, false)
+}
+
+// Mean returns the mean value of all elements of the Variable.
+func Mean[T nune.Float](v *Variable[T]) *Variable[T] {
+	return MeanAxis(v, nil, false)
+}
+
+// Prod returns the product of all elements of the Variable.
+func Prod[T nune.Float](v *Variable[T]) *Variable[T] {
+	return ProdAxis(v, nil, false)
+}
+
+// Min returns the minimum value of all elements of the Variable.
+func Min[T nune.Float](v *Variable[T]) *Variable[T] {
+	return MinAxis(v, nil, false)
+}
+
+// Max returns the maximum value of all elements of the Variable.
+func Max[T nune.Float](v *Variable[T]) *Variable[T] {
+	return MaxAxis(v, nil, false)
+}
+
+// SumAxis returns the sum of the elements of the Variable over the
+// given axes, or over all axes if none are given, following the
+// semantics of the Tensor's SumAxis method.
+func SumAxis[T nune.Float](v *Variable[T], axes []int, keepDims bool) *Variable[T] {
+	return record(v.Value.SumAxis(axes, keepDims), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			expand(g, v.Value.Shape(), axes).Copy(),
+		}
+	}, v)
+}
+
+// MeanAxis returns the mean value of the elements of the Variable over
+// the given axes, or over all axes if none are given, following the
+// semantics of the Tensor's MeanAxis method.
+func MeanAxis[T nune.Float](v *Variable[T], axes []int, keepDims bool) *Variable[T] {
+	res := v.Value.MeanAxis(axes, keepDims)
+	n := T(v.Value.Numel() / res.Numel())
+
+	return record(res, func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			tensor.PwiseOp(expand(g, v.Value.Shape(), axes), func(x T) T {
+				return x / n
+			}),
+		}
+	}, v)
+}
+
+// ProdAxis returns the product of the elements of the Variable over the
+// given axes, or over all axes if none are given, following the
+// semantics of the Tensor's ProdAxis method.
+func ProdAxis[T nune.Float](v *Variable[T], axes []int, keepDims bool) *Variable[T] {
+	return record(v.Value.ProdAxis(axes, keepDims), func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			tensor.Mul(expand(g, v.Value.Shape(), axes), prodOthers(v.Value, axes)),
+		}
+	}, v)
+}
+
+// MinAxis returns the minimum value of the elements of the Variable over
+// the given axes, or over all axes if none are given, following the
+// semantics of the Tensor's MinAxis method. The gradient is shared
+// evenly between the elements equal to the minimum.
+func MinAxis[T nune.Float](v *Variable[T], axes []int, keepDims bool) *Variable[T] {
+	res := v.Value.MinAxis(axes, keepDims)
+
+	return record(res, func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			extremumGrad(g, v.Value, res, axes),
+		}
+	}, v)
+}
+
+// MaxAxis returns the maximum value of the elements of the Variable over
+// the given axes, or over all axes if none are given, following the
+// semantics of the Tensor's MaxAxis method. The gradient is shared
+// evenly between the elements equal to the maximum.
+func MaxAxis[T nune.Float](v *Variable[T], axes []int, keepDims bool) *Variable[T] {
+	res := v.Value.MaxAxis(axes, keepDims)
+
+	return record(res, func(g *tensor.Tensor[T]) []*tensor.Tensor[T] {
+		return []*tensor.Tensor[T]{
+			extremumGrad(g, v.Value, res, axes),
+		}
+	}, v)
+}
+
+// expand broadcasts the gradient of a reduction over the given axes
+// back to the shape of the reduced Tensor.
+func expand[T nune.Float](g *tensor.Tensor[T], shape []int, axes []int) *tensor.Tensor[T] {
+	kept := slice.Copy(shape)
+	for i := range kept {
+		if len(axes) == 0 {
+			kept[i] = 1
+		}
+	}
+	for _, a := range axes {
+		kept[a] = 1
+	}
+
+	return g.Reshape(kept...).BroadcastTo(shape...)
+}
+
+// extremumGrad returns the gradient of a minimum or maximum reduction
+// res of the Tensor t, shared evenly between the extremal elements.
+func extremumGrad[T nune.Float](g, t, res *tensor.Tensor[T], axes []int) *tensor.Tensor[T] {
+	mask := tensor.PwiseOp(tensor.Sub(t, expand(res, t.Shape(), axes)), func(x T) T {
+		if x == 0 {
+			return 1
+		}
+		return 0
+	})
+
+	count := expand(mask.SumAxis(axes, false), t.Shape(), axes)
+
+	return tensor.Div(tensor.Mul(expand(g, t.Shape(), axes), mask), count)
+}
+
+// prodOthers returns, for each element of the Tensor, the product of
+// the other elements reduced along with it over the given axes.
+func prodOthers[T nune.Float](t *tensor.Tensor[T], axes []int) *tensor.Tensor[T] {
+	reduced := make([]bool, t.Rank())
+	for i := range reduced {
+		reduced[i] = len(axes) == 0
+	}
+	for _, a := range axes {
+		reduced[a] = true
+	}
+
+	var perm []int
+	for pass := 0; pass < 2; pass++ {
+		for i, r := range reduced {
+			if r == (pass == 1) {
+				perm = append(perm, i)
+			}
+		}
+	}
+
+	p := t.Permute(perm...)
+	data := p.Ravel()
+
+	m := 1
+	for i, a := range perm {
+		if reduced[a] {
+			m *= p.Size(i)
+		}
+	}
+
+	res := make([]T, len(data))
+	for lane := 0; lane < len(data); lane += m {
+		// exclusive prefix products, then suffix products
+		acc := T(1)
+		for i := lane; i < lane+m; i++ {
+			res[i] = acc
+			acc *= data[i]
+		}
+
+		acc = 1
+		for i := lane + m - 1; i >= lane; i-- {
+			res[i] *= acc
+			acc *= data[i]
+		}
+	}
+
+	inv := make([]int, len(perm))
+	for i, a := range perm {
+		inv[a] = i
+	}
+
+	return tensor.FromBuffer(res, p.Shape()...).Permute(inv...)
+}
@@ -0,0 +1,72 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// scopes counts the NoGrad scopes open in each goroutine,
+// by goroutine id.
+var scopes = struct {
+	sync.RWMutex
+	open map[uint64]int
+}{open: make(map[uint64]int)}
+
+// NoGrad calls f in a scope without gradients: the operations performed
+// by f on Variables are not recorded, and their results require no
+// gradients. The scope only covers the calling goroutine, such that
+// evaluating a model within it doesn't affect computations running
+// concurrently on the same parameters, but neither does it cover
+// goroutines started by f. Scopes may be nested.
+func NoGrad(f func()) {
+	id := goid()
+
+	scopes.Lock()
+	scopes.open[id]++
+	scopes.Unlock()
+
+	defer func() {
+		scopes.Lock()
+		if scopes.open[id]--; scopes.open[id] == 0 {
+			delete(scopes.open, id)
+		}
+		scopes.Unlock()
+	}()
+
+	f()
+}
+
+// inNoGrad returns whether or not the calling
+// goroutine is within a NoGrad scope.
+func inNoGrad() bool {
+	scopes.RLock()
+	defer scopes.RUnlock()
+
+	if len(scopes.open) == 0 {
+		return false
+	}
+
+	return scopes.open[goid()] != 0
+}
+
+// goid returns the id of the calling goroutine, as found
+// in the header of its stack trace: "goroutine 42 [running]:".
+func goid() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+
+	return id
+}
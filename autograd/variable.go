@@ -0,0 +1,229 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// A Variable is a Tensor which records the operations it takes part in,
+// so that the gradients of their results can be propagated back to it.
+type Variable[T nune.Float] struct {
+	Value *tensor.Tensor[T] // the Variable's value
+	Grad  *tensor.Tensor[T] // the accumulated gradient, nil until computed
+
+	requiresGrad bool
+	noGrad       bool     // whether or not the operations the Variable takes part in are recorded
+	node         *node[T] // the operation which produced the Variable, nil for leaves
+}
+
+// A node records an operation and how to propagate
+// gradients to its operands.
+type node[T nune.Float] struct {
+	parents []*Variable[T]
+
+	// backward returns the gradients of the operands
+	// given the gradient of the operation's result.
+	backward func(grad *tensor.Tensor[T]) []*tensor.Tensor[T]
+}
+
+// New returns a leaf Variable holding the given Tensor, which
+// accumulates gradients in its Grad field if requiresGrad is true.
+func New[T nune.Float](t *tensor.Tensor[T], requiresGrad bool) *Variable[T] {
+	return &Variable[T]{
+		Value:        t,
+		requiresGrad: requiresGrad,
+	}
+}
+
+// RequiresGrad returns whether or not gradients
+// are propagated to the Variable.
+func (v *Variable[T]) RequiresGrad() bool {
+	return v.requiresGrad
+}
+
+// IsLeaf returns whether or not the Variable was created by the
+// user rather than by a recorded operation.
+func (v *Variable[T]) IsLeaf() bool {
+	return v.node == nil
+}
+
+// Detach returns a new Variable sharing the Variable's value,
+// but detached from its history and requiring no gradients.
+func (v *Variable[T]) Detach() *Variable[T] {
+	return New(v.Value, false)
+}
+
+// NoGrad returns a new Variable sharing the Variable's value, whose
+// operations are not recorded, whatever their other operands, and
+// neither are those which their results take part in. Evaluating a model
+// on an input passed through NoGrad thus records nothing, even if its
+// parameters require gradients, as the NoGrad function does, but for
+// the computations flowing from that input only.
+func (v *Variable[T]) NoGrad() *Variable[T] {
+	return &Variable[T]{
+		Value:  v.Value,
+		noGrad: true,
+	}
+}
+
+// ZeroGrad resets the Variable's accumulated gradient.
+func (v *Variable[T]) ZeroGrad() {
+	v.Grad = nil
+}
+
+// Backward computes the gradients of the Variable with respect to
+// every leaf Variable requiring gradients it was computed from, and
+// accumulates them into their Grad fields. The Variable must be a scalar,
+// unless the gradient of the final result with respect to it is given,
+// or Backward panics with ErrBackward.
+func (v *Variable[T]) Backward(grad ...*tensor.Tensor[T]) {
+	if len(grad) > 1 {
+		panic(ErrGradients)
+	}
+
+	var g *tensor.Tensor[T]
+	if len(grad) == 1 {
+		if !slice.Equal(grad[0].Shape(), v.Value.Shape()) {
+			panic(ErrBackward)
+		}
+		g = grad[0]
+	} else if v.Value.Numel() == 1 {
+		g = full(v.Value.Shape(), T(1))
+	} else {
+		panic(ErrBackward)
+	}
+
+	if !v.requiresGrad {
+		return
+	}
+
+	// order the Variables such that each comes before its parents
+	var order []*Variable[T]
+	visited := make(map[*Variable[T]]bool)
+
+	var visit func(*Variable[T])
+	visit = func(x *Variable[T]) {
+		if visited[x] || !x.requiresGrad {
+			return
+		}
+		visited[x] = true
+
+		if x.node != nil {
+			for _, p := range x.node.parents {
+				visit(p)
+			}
+		}
+
+		order = append(order, x)
+	}
+	visit(v)
+
+	grads := map[*Variable[T]]*tensor.Tensor[T]{v: g}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		x := order[i]
+
+		g, ok := grads[x]
+		if !ok {
+			continue
+		}
+		delete(grads, x)
+
+		if x.node == nil {
+			x.accumulate(g)
+			continue
+		}
+
+		for j, pg := range x.node.backward(g) {
+			p := x.node.parents[j]
+			if pg == nil || !p.requiresGrad {
+				continue
+			}
+
+			if acc, ok := grads[p]; ok {
+				grads[p] = tensor.Add(acc, pg)
+			} else {
+				grads[p] = pg
+			}
+		}
+	}
+}
+
+// accumulate adds the gradient to the Variable's Grad field.
+func (v *Variable[T]) accumulate(g *tensor.Tensor[T]) {
+	if v.Grad == nil {
+		v.Grad = g.Copy()
+	} else {
+		v.Grad = tensor.Add(v.Grad, g)
+	}
+}
+
+// record returns a Variable holding the result of an operation over
+// the given operands, which records the operation if any of them
+// requires gradients.
+func record[T nune.Float](value *tensor.Tensor[T], backward func(*tensor.Tensor[T]) []*tensor.Tensor[T], parents ...*Variable[T]) *Variable[T] {
+	v := New(value, false)
+	if inNoGrad() {
+		return v
+	}
+
+	for _, p := range parents {
+		if p.noGrad {
+			v.noGrad = true
+			return v
+		}
+	}
+
+	for _, p := range parents {
+		if p.requiresGrad {
+			v.requiresGrad = true
+			v.node = &node[T]{
+				parents:  parents,
+				backward: backward,
+			}
+
+			break
+		}
+	}
+
+	return v
+}
+
+// full returns a Tensor of the given shape, which may be empty,
+// filled with the given value.
+func full[T nune.Float](shape []int, x T) *tensor.Tensor[T] {
+	data := make([]T, slice.Prod(shape))
+	for i := range data {
+		data[i] = x
+	}
+
+	return tensor.FromBuffer(data, shape...)
+}
+
+// unbroadcast sums the gradient over the axes along which
+// an operand of the given shape was broadcasted.
+func unbroadcast[T nune.Float](g *tensor.Tensor[T], shape []int) *tensor.Tensor[T] {
+	if slice.Equal(g.Shape(), shape) {
+		return g
+	}
+
+	lead := g.Rank() - len(shape)
+
+	var axes []int
+	for i := 0; i < g.Rank(); i++ {
+		if i < lead || shape[i-lead] == 1 && g.Size(i) != 1 {
+			axes = append(axes, i)
+		}
+	}
+
+	if len(axes) != 0 {
+		g = g.SumAxis(axes, true)
+	}
+
+	return g.Reshape(shape...)
+}
@@ -0,0 +1,126 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+func TestNoGrad(t *testing.T) {
+	w := New(tensor.FromBuffer([]float64{1, 2, 3}, 3), true)
+	x := New(tensor.FromBuffer([]float64{4, 5, 6}, 3), false)
+
+	y := Sum(Mul(Exp(x.NoGrad()), w))
+	if y.RequiresGrad() || !y.IsLeaf() {
+		t.Fatal("operations on a NoGrad Variable were recorded")
+	}
+
+	y.Backward()
+	if w.Grad != nil {
+		t.Fatalf("got gradient %v through a NoGrad Variable", w.Grad)
+	}
+
+	// the same parameters are still recorded outside of the scope
+	z := Sum(Mul(x, w))
+	z.Backward()
+	if !z.RequiresGrad() || w.Grad == nil || !reflect.DeepEqual(w.Grad.Ravel(), []float64{4, 5, 6}) {
+		t.Fatalf("got gradient %v, want [4 5 6]", w.Grad)
+	}
+}
+
+func TestNoGradConcurrent(t *testing.T) {
+	w := New(tensor.FromBuffer([]float64{1, 2, 3}, 3), true)
+	x := New(tensor.FromBuffer([]float64{4, 5, 6}, 3), false)
+
+	// evaluating without gradients doesn't affect training
+	// running concurrently on the same parameters
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			Sum(Mul(x.NoGrad(), w))
+			NoGrad(func() { Sum(Mul(x, w)) })
+		}
+	}()
+
+	grads := make([]*tensor.Tensor[float64], 100)
+	for i := range grads {
+		y := Sum(Mul(x, w))
+		if !y.RequiresGrad() {
+			t.Fatal("operations weren't recorded while evaluating without gradients")
+		}
+		y.Backward()
+		grads[i] = w.Grad
+		w.ZeroGrad()
+	}
+	wg.Wait()
+
+	for _, g := range grads {
+		if g == nil || !reflect.DeepEqual(g.Ravel(), []float64{4, 5, 6}) {
+			t.Fatalf("got gradient %v, want [4 5 6]", g)
+		}
+	}
+}
+
+func TestNoGradScope(t *testing.T) {
+	w := New(tensor.FromBuffer([]float64{1, 2, 3}, 3), true)
+
+	var inner, outer *Variable[float64]
+	NoGrad(func() {
+		NoGrad(func() {
+			inner = Sum(w)
+		})
+		outer = Mul(Sum(w), Sum(w))
+	})
+
+	for _, v := range []*Variable[float64]{inner, outer} {
+		if v.RequiresGrad() || !v.IsLeaf() {
+			t.Fatal("operations within a NoGrad scope were recorded")
+		}
+	}
+
+	// the scope is closed even if f panics
+	func() {
+		defer func() { recover() }()
+		NoGrad(func() { panic("f") })
+	}()
+
+	if y := Sum(w); !y.RequiresGrad() {
+		t.Fatal("operations after a NoGrad scope weren't recorded")
+	}
+}
+
+func TestBackwardErrors(t *testing.T) {
+	w := New(tensor.FromBuffer([]float64{1, 2, 3}, 3), true)
+	y := Mul(w, w)
+
+	tests := []struct {
+		name  string
+		grads []*tensor.Tensor[float64]
+		err   error
+	}{
+		{"non-scalar", nil, ErrBackward},
+		{"bad shape", []*tensor.Tensor[float64]{tensor.Ones[float64](2)}, ErrBackward},
+		{"two gradients", []*tensor.Tensor[float64]{tensor.Ones[float64](3), tensor.Ones[float64](3)}, ErrGradients},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, tt.err) {
+					t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+				}
+			}()
+
+			y.Backward(tt.grads...)
+		}()
+	}
+}
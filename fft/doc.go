@@ -0,0 +1,18 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fft implements discrete Fourier transforms of Tensors
+// along one or more of their axes.
+//
+// Transforms of any length are computed in O(n log n) operations,
+// through a mixed-radix Cooley–Tukey algorithm, or through Bluestein's
+// algorithm for lengths having large prime factors. The plans driving
+// them are computed once per length and cached for reuse.
+//
+// As in NumPy, forward transforms are unnormalized while inverse ones
+// are scaled by 1/n, and real-input transforms only keep the n/2+1
+// non-negative frequency terms. Computations are carried out in double
+// precision. Unlike the tensor package, invalid inputs are reported
+// through returned errors rather than panics.
+package fft
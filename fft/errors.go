@@ -0,0 +1,25 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import "errors"
+
+// List of errors.
+var (
+	// ErrAxisBounds occurs when an axis is out of
+	// (0, tensor rank) bounds.
+	ErrAxisBounds = errors.New("nune/fft: axis out of bounds")
+
+	// ErrBadAxes occurs when a list of axes contains duplicates.
+	ErrBadAxes = errors.New("nune/fft: received bad axes")
+
+	// ErrBadLength occurs when a transform or its
+	// sample frequencies are given a null or negative length.
+	ErrBadLength = errors.New("nune/fft: received a bad length")
+
+	// ErrBadSpacing occurs when sample frequencies
+	// are given a null sample spacing.
+	ErrBadSpacing = errors.New("nune/fft: received a null sample spacing")
+)
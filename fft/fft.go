@@ -0,0 +1,317 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math/cmplx"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// FFT returns the discrete Fourier transform of the
+// ComplexTensor along the given axis.
+func FFT[T nune.Complex](x *tensor.ComplexTensor[T], axis int) (*tensor.ComplexTensor[T], error) {
+	return FFTN(x, axis)
+}
+
+// IFFT returns the inverse discrete Fourier transform
+// of the ComplexTensor along the given axis.
+func IFFT[T nune.Complex](x *tensor.ComplexTensor[T], axis int) (*tensor.ComplexTensor[T], error) {
+	return IFFTN(x, axis)
+}
+
+// FFT2 returns the 2-dimensional discrete Fourier transform
+// of the ComplexTensor along its last two axes.
+func FFT2[T nune.Complex](x *tensor.ComplexTensor[T]) (*tensor.ComplexTensor[T], error) {
+	return FFTN(x, x.Rank()-2, x.Rank()-1)
+}
+
+// IFFT2 returns the 2-dimensional inverse discrete Fourier
+// transform of the ComplexTensor along its last two axes.
+func IFFT2[T nune.Complex](x *tensor.ComplexTensor[T]) (*tensor.ComplexTensor[T], error) {
+	return IFFTN(x, x.Rank()-2, x.Rank()-1)
+}
+
+// FFTN returns the n-dimensional discrete Fourier transform of
+// the ComplexTensor along the given axes, or all of its axes if none
+// are given.
+func FFTN[T nune.Complex](x *tensor.ComplexTensor[T], axes ...int) (*tensor.ComplexTensor[T], error) {
+	return fftn(x, axes, false)
+}
+
+// IFFTN returns the n-dimensional inverse discrete Fourier transform
+// of the ComplexTensor along the given axes, or all of its axes if none
+// are given.
+func IFFTN[T nune.Complex](x *tensor.ComplexTensor[T], axes ...int) (*tensor.ComplexTensor[T], error) {
+	return fftn(x, axes, true)
+}
+
+// RFFT returns the discrete Fourier transform of the real Tensor along
+// the given axis, holding only the n/2+1 non-negative frequency terms,
+// the others being their complex conjugates.
+func RFFT[T nune.Numeric](x *tensor.Tensor[T], axis int) (*tensor.ComplexTensor[complex128], error) {
+	return RFFTN(x, axis)
+}
+
+// IRFFT returns the inverse of RFFT along the given axis, a real Tensor
+// whose length along that axis is n. The ComplexTensor holds the n/2+1
+// non-negative frequency terms, missing ones being zero. If n is 0, it
+// defaults to 2(m-1), where m is the length of the ComplexTensor's axis.
+func IRFFT[T nune.Complex](x *tensor.ComplexTensor[T], n, axis int) (*tensor.Tensor[float64], error) {
+	return IRFFTN(x, n, axis)
+}
+
+// RFFT2 returns the 2-dimensional discrete Fourier transform of the
+// real Tensor along its last two axes, as by RFFTN.
+func RFFT2[T nune.Numeric](x *tensor.Tensor[T]) (*tensor.ComplexTensor[complex128], error) {
+	return RFFTN(x, x.Rank()-2, x.Rank()-1)
+}
+
+// IRFFT2 returns the inverse of RFFT2, as by IRFFTN.
+func IRFFT2[T nune.Complex](x *tensor.ComplexTensor[T], n int) (*tensor.Tensor[float64], error) {
+	return IRFFTN(x, n, x.Rank()-2, x.Rank()-1)
+}
+
+// RFFTN returns the n-dimensional discrete Fourier transform of the real
+// Tensor along the given axes, or all of its axes if none are given.
+// Only the non-negative frequency terms of the last axis are kept.
+func RFFTN[T nune.Numeric](x *tensor.Tensor[T], axes ...int) (*tensor.ComplexTensor[complex128], error) {
+	axes, err := checkAxes(x.Rank(), axes)
+	if err != nil {
+		return nil, err
+	}
+
+	data := x.Ravel()
+	a := array{data: make([]complex128, len(data)), shape: x.Shape()}
+	for i, v := range data {
+		a.data[i] = complex(float64(v), 0)
+	}
+
+	if len(axes) == 0 {
+		return tensor.ComplexFromBuffer(a.data, a.shape...), nil
+	}
+
+	last := axes[len(axes)-1]
+	a.transform(last, false)
+	a = a.resize(last, a.shape[last]/2+1)
+
+	for _, axis := range axes[:len(axes)-1] {
+		a.transform(axis, false)
+	}
+
+	return tensor.ComplexFromBuffer(a.data, a.shape...), nil
+}
+
+// IRFFTN returns the inverse of RFFTN along the given axes, or all of
+// the ComplexTensor's axes if none are given, a real Tensor whose length
+// along the last axis is n, as by IRFFT.
+func IRFFTN[T nune.Complex](x *tensor.ComplexTensor[T], n int, axes ...int) (*tensor.Tensor[float64], error) {
+	axes, err := checkAxes(x.Rank(), axes)
+	if err != nil {
+		return nil, err
+	}
+
+	a := fromComplex(x)
+
+	if len(axes) == 0 {
+		return tensor.FromBuffer(a.real(), a.shape...), nil
+	}
+
+	last := axes[len(axes)-1]
+	if n == 0 {
+		n = 2 * (a.shape[last] - 1)
+	}
+	if n <= 0 {
+		return nil, ErrBadLength
+	}
+
+	for _, axis := range axes[:len(axes)-1] {
+		a.transform(axis, true)
+	}
+
+	a = a.hermitian(last, n)
+	a.transform(last, true)
+
+	return tensor.FromBuffer(a.real(), a.shape...), nil
+}
+
+// fftn computes the forward or inverse transform
+// of the ComplexTensor along the given axes.
+func fftn[T nune.Complex](x *tensor.ComplexTensor[T], axes []int, inverse bool) (*tensor.ComplexTensor[T], error) {
+	axes, err := checkAxes(x.Rank(), axes)
+	if err != nil {
+		return nil, err
+	}
+
+	a := fromComplex(x)
+	for _, axis := range axes {
+		a.transform(axis, inverse)
+	}
+
+	data := make([]T, len(a.data))
+	for i, v := range a.data {
+		data[i] = T(v)
+	}
+
+	return tensor.ComplexFromBuffer(data, a.shape...), nil
+}
+
+// checkAxes returns the given axes of a Tensor of the given rank,
+// or all of its axes if none are given, checking their validity.
+func checkAxes(rank int, axes []int) ([]int, error) {
+	if len(axes) == 0 {
+		axes = make([]int, rank)
+		for i := range axes {
+			axes[i] = i
+		}
+
+		return axes, nil
+	}
+
+	seen := make([]bool, rank)
+	for _, axis := range axes {
+		if axis < 0 || axis >= rank {
+			return nil, ErrAxisBounds
+		}
+		if seen[axis] {
+			return nil, ErrBadAxes
+		}
+		seen[axis] = true
+	}
+
+	return axes, nil
+}
+
+// An array holds the elements of a Tensor being transformed,
+// in row-major order.
+type array struct {
+	data  []complex128
+	shape []int
+}
+
+// fromComplex copies the ComplexTensor's elements into an array.
+func fromComplex[T nune.Complex](x *tensor.ComplexTensor[T]) array {
+	data := x.Ravel()
+
+	a := array{data: make([]complex128, len(data)), shape: x.Shape()}
+	for i, v := range data {
+		a.data[i] = complex128(v)
+	}
+
+	return a
+}
+
+// real returns the real parts of the array's elements.
+func (a array) real() []float64 {
+	r := make([]float64, len(a.data))
+	for i, v := range a.data {
+		r[i] = real(v)
+	}
+
+	return r
+}
+
+// lines returns the number of lines of the array along
+// the given axis, and the stride between their elements.
+func (a array) lines(axis int) (int, int) {
+	inner := 1
+	for _, d := range a.shape[axis+1:] {
+		inner *= d
+	}
+
+	return len(a.data) / a.shape[axis], inner
+}
+
+// start returns the position of the first element of
+// the i-th line of an array whose lines have the given
+// length and stride.
+func start(i, n, stride int) int {
+	return i/stride*n*stride + i%stride
+}
+
+// transform replaces the lines of the array along the given axis
+// by their forward or inverse transforms, concurrently.
+func (a array) transform(axis int, inverse bool) {
+	n := a.shape[axis]
+	if n == 1 {
+		return
+	}
+
+	p := planFor(n)
+	lines, stride := a.lines(axis)
+
+	cpd.Parallel(lines, 1+(1<<12)/n, func(first, last int) {
+		src := make([]complex128, n)
+		dst := make([]complex128, n)
+		scratch := make([]complex128, p.work())
+
+		for i := first; i < last; i++ {
+			s := start(i, n, stride)
+
+			for k := range src {
+				src[k] = a.data[s+k*stride]
+			}
+
+			if inverse {
+				p.inverse(dst, src, scratch)
+			} else {
+				p.forward(dst, src, scratch)
+			}
+
+			for k, v := range dst {
+				a.data[s+k*stride] = v
+			}
+		}
+	})
+}
+
+// resize returns an array whose lines along the given axis are
+// those of the array truncated or zero-padded to length n.
+func (a array) resize(axis, n int) array {
+	return a.reshape(axis, n, func(dst, src []complex128, stride int) {
+		for k := 0; k < n && k < len(src); k++ {
+			dst[k*stride] = src[k]
+		}
+	})
+}
+
+// hermitian returns an array whose lines along the given axis are
+// the Hermitian-symmetric sequences of length n whose first n/2+1
+// terms are those of the array, zero-padded if needed.
+func (a array) hermitian(axis, n int) array {
+	return a.reshape(axis, n, func(dst, src []complex128, stride int) {
+		for k := 0; k <= n/2 && k < len(src); k++ {
+			dst[k*stride] = src[k]
+		}
+		for k := n/2 + 1; k < n; k++ {
+			dst[k*stride] = cmplx.Conj(dst[(n-k)*stride])
+		}
+	})
+}
+
+// reshape returns an array whose lines along the given axis
+// have length n, filled by f from the array's lines.
+// The lines passed to f are contiguous for src, and strided for dst.
+func (a array) reshape(axis, n int, f func(dst, src []complex128, stride int)) array {
+	m := a.shape[axis]
+	lines, stride := a.lines(axis)
+
+	b := array{data: make([]complex128, lines*n), shape: append([]int{}, a.shape...)}
+	b.shape[axis] = n
+
+	src := make([]complex128, m)
+	for i := 0; i < lines; i++ {
+		s := start(i, m, stride)
+		for k := range src {
+			src[k] = a.data[s+k*stride]
+		}
+
+		f(b.data[start(i, n, stride):], src, stride)
+	}
+
+	return b
+}
@@ -0,0 +1,201 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+// signal returns n deterministic, irregular complex samples.
+func signal(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		f := float64(i)
+		x[i] = complex(math.Sin(1.3*f)+0.25*f, math.Cos(0.7*f*f)-0.5)
+	}
+
+	return x
+}
+
+// dft returns the discrete Fourier transform of x computed from its
+// definition, or the inverse transform if inverse is true.
+func dft(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+
+	y := make([]complex128, n)
+	for k := range y {
+		for j, v := range x {
+			y[k] += v * cmplx.Rect(1, sign*2*math.Pi*float64(j*k%n)/float64(n))
+		}
+		if inverse {
+			y[k] /= complex(float64(n), 0)
+		}
+	}
+
+	return y
+}
+
+// approx returns whether or not the two slices have the same length,
+// and elements which differ by no more than a tolerance relative to n.
+func approx(x, y []complex128, n int) bool {
+	if len(x) != len(y) {
+		return false
+	}
+
+	tol := 1e-10 * float64(n)
+	for i := range x {
+		if cmplx.Abs(x[i]-y[i]) > tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestFFT(t *testing.T) {
+	lengths := []int{
+		1, 2, 3, 4, 5, 8, 12, 16, 30, 31, 64, 210, // radix
+		37, 74, 97, 202, 127, 1009, // Bluestein
+	}
+
+	for _, n := range lengths {
+		x := signal(n)
+		c := tensor.ComplexFromBuffer(x, n)
+
+		y, err := FFT(c, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := dft(x, false); !approx(y.Ravel(), want, n) {
+			t.Errorf("n = %d: FFT differs from the DFT", n)
+		}
+
+		z, err := IFFT(c, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := dft(x, true); !approx(z.Ravel(), want, n) {
+			t.Errorf("n = %d: IFFT differs from the inverse DFT", n)
+		}
+
+		back, err := IFFT(y, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !approx(back.Ravel(), x, n) {
+			t.Errorf("n = %d: IFFT(FFT(x)) differs from x", n)
+		}
+	}
+}
+
+func TestFFTN(t *testing.T) {
+	// a 3×37×4 signal, transformed along the last two axes,
+	// one of which needs Bluestein's algorithm
+	m, n, p := 3, 37, 4
+	x := signal(m * n * p)
+	c := tensor.ComplexFromBuffer(x, m, n, p)
+
+	y, err := FFTN(c, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := make([]complex128, len(x))
+	for i := 0; i < m; i++ {
+		mat := x[i*n*p : (i+1)*n*p]
+
+		rows := make([]complex128, n*p)
+		for r := 0; r < n; r++ {
+			copy(rows[r*p:], dft(mat[r*p:(r+1)*p], false))
+		}
+
+		for col := 0; col < p; col++ {
+			line := make([]complex128, n)
+			for r := range line {
+				line[r] = rows[r*p+col]
+			}
+			for r, v := range dft(line, false) {
+				want[i*n*p+r*p+col] = v
+			}
+		}
+	}
+
+	if !approx(y.Ravel(), want, n*p) {
+		t.Error("FFTN differs from the DFT along each axis")
+	}
+
+	back, err := IFFT2(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !approx(back.Ravel(), x, n*p) {
+		t.Error("IFFT2(FFTN(x)) differs from x")
+	}
+}
+
+func TestRFFT(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 16, 41, 82} {
+		x := signal(n)
+		re := make([]float64, n)
+		for i, v := range x {
+			re[i] = real(v)
+			x[i] = complex(real(v), 0)
+		}
+
+		y, err := RFFT(tensor.FromBuffer(re, n), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := dft(x, false)[:n/2+1]; !approx(y.Ravel(), want, n) {
+			t.Errorf("n = %d: RFFT differs from the DFT", n)
+		}
+
+		back, err := IRFFT(y, n, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range back.Ravel() {
+			if math.Abs(v-re[i]) > 1e-10*float64(n) {
+				t.Fatalf("n = %d: IRFFT(RFFT(x)) = %v, want %v", n, back.Ravel(), re)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	c := tensor.ComplexFromBuffer(signal(6), 2, 3)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"axis out of bounds", second(FFT(c, 2)), ErrAxisBounds},
+		{"duplicate axes", second(FFTN(c, 1, 1)), ErrBadAxes},
+		{"bad length", second(IRFFT(tensor.ComplexFromBuffer(signal(1), 1), 0, 0)), ErrBadLength},
+		{"null length", second(FFTFreq(0, 1)), ErrBadLength},
+		{"null spacing", second(RFFTFreq(4, 0)), ErrBadSpacing},
+	}
+
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+// second returns the second of its arguments.
+func second[T any](_ T, err error) error {
+	return err
+}
@@ -0,0 +1,141 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// FFTFreq returns the sample frequencies of the terms of a transform of
+// length n, whose samples are spaced by d: [0, 1, ..., n/2-1, -n/2, ..., -1]
+// divided by d*n for even n, and [0, 1, ..., (n-1)/2, -(n-1)/2, ..., -1]
+// divided by d*n for odd n.
+func FFTFreq(n int, d float64) (*tensor.Tensor[float64], error) {
+	if err := checkFreq(n, d); err != nil {
+		return nil, err
+	}
+
+	freq := make([]float64, n)
+	for k := range freq {
+		j := k
+		if k > (n-1)/2 {
+			j = k - n
+		}
+		freq[k] = float64(j) / (d * float64(n))
+	}
+
+	return tensor.FromBuffer(freq, n), nil
+}
+
+// RFFTFreq returns the sample frequencies of the terms of a transform
+// computed by RFFT over n samples spaced by d: [0, 1, ..., n/2]
+// divided by d*n.
+func RFFTFreq(n int, d float64) (*tensor.Tensor[float64], error) {
+	if err := checkFreq(n, d); err != nil {
+		return nil, err
+	}
+
+	freq := make([]float64, n/2+1)
+	for k := range freq {
+		freq[k] = float64(k) / (d * float64(n))
+	}
+
+	return tensor.FromBuffer(freq, len(freq)), nil
+}
+
+// checkFreq checks the length and sample spacing of sample frequencies.
+func checkFreq(n int, d float64) error {
+	if n <= 0 {
+		return ErrBadLength
+	}
+	if d == 0 {
+		return ErrBadSpacing
+	}
+
+	return nil
+}
+
+// FFTShift returns the ComplexTensor with its zero-frequency terms
+// shifted to the center of the given axes, or of all of its axes
+// if none are given, as to order them by increasing frequency.
+func FFTShift[T nune.Complex](x *tensor.ComplexTensor[T], axes ...int) (*tensor.ComplexTensor[T], error) {
+	data, err := shift(x.Ravel(), x.Shape(), axes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return tensor.ComplexFromBuffer(data, x.Shape()...), nil
+}
+
+// IFFTShift is the inverse of FFTShift.
+func IFFTShift[T nune.Complex](x *tensor.ComplexTensor[T], axes ...int) (*tensor.ComplexTensor[T], error) {
+	data, err := shift(x.Ravel(), x.Shape(), axes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return tensor.ComplexFromBuffer(data, x.Shape()...), nil
+}
+
+// FFTShiftReal is the counterpart of FFTShift for real Tensors,
+// such as the sample frequencies returned by FFTFreq.
+func FFTShiftReal[T nune.Numeric](x *tensor.Tensor[T], axes ...int) (*tensor.Tensor[T], error) {
+	data, err := shift(x.Ravel(), x.Shape(), axes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return tensor.FromBuffer(data, x.Shape()...), nil
+}
+
+// IFFTShiftReal is the inverse of FFTShiftReal.
+func IFFTShiftReal[T nune.Numeric](x *tensor.Tensor[T], axes ...int) (*tensor.Tensor[T], error) {
+	data, err := shift(x.Ravel(), x.Shape(), axes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return tensor.FromBuffer(data, x.Shape()...), nil
+}
+
+// shift rolls the row-major elements of the given shape by half the
+// length of each of the given axes, or of all axes if none are given,
+// forward, or backward if inverse is true.
+func shift[T nune.Number](data []T, shape, axes []int, inverse bool) ([]T, error) {
+	axes, err := checkAxes(len(shape), axes)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts := make([]int, len(shape))
+	for _, axis := range axes {
+		shifts[axis] = shape[axis] / 2
+		if inverse {
+			shifts[axis] = shape[axis] - shifts[axis]
+		}
+	}
+
+	res := make([]T, len(data))
+	index := make([]int, len(shape))
+
+	for _, x := range data {
+		pos := 0
+		for i, idx := range index {
+			pos = pos*shape[i] + (idx+shifts[i])%shape[i]
+		}
+		res[pos] = x
+
+		for i := len(index) - 1; i >= 0; i-- {
+			index[i]++
+			if index[i] < shape[i] {
+				break
+			}
+			index[i] = 0
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,58 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+func TestFreq(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() (*tensor.Tensor[float64], error)
+		want []float64
+	}{
+		{"even", func() (*tensor.Tensor[float64], error) { return FFTFreq(4, 0.5) }, []float64{0, 0.5, -1, -0.5}},
+		{"odd", func() (*tensor.Tensor[float64], error) { return FFTFreq(5, 1) }, []float64{0, 0.2, 0.4, -0.4, -0.2}},
+		{"real even", func() (*tensor.Tensor[float64], error) { return RFFTFreq(4, 1) }, []float64{0, 0.25, 0.5}},
+		{"real odd", func() (*tensor.Tensor[float64], error) { return RFFTFreq(5, 0.1) }, []float64{0, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.got()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, v := range got.Ravel() {
+			if d := v - tt.want[i]; d > 1e-12 || d < -1e-12 || got.Size() != len(tt.want) {
+				t.Fatalf("%s: got %v, want %v", tt.name, got.Ravel(), tt.want)
+			}
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	x := tensor.Range[int](0, 5, 1)
+
+	s, err := FFTShiftReal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Ravel(); !reflect.DeepEqual(got, []int{3, 4, 0, 1, 2}) {
+		t.Errorf("FFTShift: got %v, want [3 4 0 1 2]", got)
+	}
+
+	back, err := IFFTShiftReal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Ravel(); !reflect.DeepEqual(got, x.Ravel()) {
+		t.Errorf("IFFTShift(FFTShift(x)): got %v, want %v", got, x.Ravel())
+	}
+}
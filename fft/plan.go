@@ -0,0 +1,244 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"sync"
+)
+
+// maxRadix is the largest prime factor of the lengths transformed through
+// the mixed-radix algorithm, other lengths going through Bluestein's.
+const maxRadix = 31
+
+// A plan holds the precomputed factors and twiddles
+// of the transforms of a given length.
+type plan struct {
+	n        int
+	factors  []int        // the radices of the Cooley–Tukey stages, nil if Bluestein's algorithm is used
+	twiddles []complex128 // the n-th roots of unity, exp(-2πik/n)
+
+	chirp  []complex128 // Bluestein's chirp, exp(-πik²/n)
+	kernel []complex128 // the transform of the conjugated chirp, scaled by 1/m
+	inner  *plan        // the plan of the power of two length m ≥ 2n-1
+}
+
+// plans caches the plans by length.
+var plans = struct {
+	sync.Mutex
+	cache map[int]*plan
+}{cache: make(map[int]*plan)}
+
+// planFor returns the cached plan of the given length,
+// computing it on first use.
+func planFor(n int) *plan {
+	plans.Lock()
+	p, ok := plans.cache[n]
+	plans.Unlock()
+
+	if ok {
+		return p
+	}
+
+	p = newPlan(n) // computed unlocked, as it may need an inner plan
+
+	plans.Lock()
+	defer plans.Unlock()
+
+	if q, ok := plans.cache[n]; ok {
+		return q
+	}
+	plans.cache[n] = p
+
+	return p
+}
+
+// newPlan computes the plan of the given length.
+func newPlan(n int) *plan {
+	p := &plan{
+		n:        n,
+		factors:  factorize(n),
+		twiddles: make([]complex128, n),
+	}
+
+	for k := range p.twiddles {
+		p.twiddles[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+	}
+
+	if p.factors != nil {
+		return p
+	}
+
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	p.inner = planFor(m)
+
+	// k² is reduced modulo 2n, over which the chirp is periodic,
+	// to keep the angles accurate for large lengths
+	p.chirp = make([]complex128, n)
+	for k := range p.chirp {
+		k2 := int64(k) * int64(k) % int64(2*n)
+		p.chirp[k] = cmplx.Rect(1, -math.Pi*float64(k2)/float64(n))
+	}
+
+	b := make([]complex128, m)
+	b[0] = cmplx.Conj(p.chirp[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(p.chirp[k])
+		b[m-k] = b[k]
+	}
+
+	p.kernel = make([]complex128, m)
+	p.inner.forward(p.kernel, b, make([]complex128, p.inner.work()))
+	for k := range p.kernel {
+		p.kernel[k] /= complex(float64(m), 0)
+	}
+
+	return p
+}
+
+// factorize returns the radices into which n is decomposed, radices
+// of 4 first, or nil if n has a prime factor larger than maxRadix.
+func factorize(n int) []int {
+	var factors []int
+
+	for n%4 == 0 {
+		factors = append(factors, 4)
+		n /= 4
+	}
+
+	for f := 2; n > 1; f++ {
+		if f > maxRadix {
+			return nil
+		}
+
+		for n%f == 0 {
+			factors = append(factors, f)
+			n /= f
+		}
+	}
+
+	return factors
+}
+
+// work returns the length of the scratch buffer used by the plan's transforms.
+func (p *plan) work() int {
+	if p.factors != nil {
+		return maxRadix
+	}
+
+	return 2*p.inner.n + p.inner.work()
+}
+
+// forward writes the forward transform of src into dst,
+// using the given scratch buffer of length p.work().
+func (p *plan) forward(dst, src, scratch []complex128) {
+	if p.factors != nil {
+		p.cooleyTukey(dst, src, 1, p.factors, scratch)
+	} else {
+		p.bluestein(dst, src, scratch)
+	}
+}
+
+// inverse writes the inverse transform of src into dst,
+// scaled by 1/n, overwriting src.
+func (p *plan) inverse(dst, src, scratch []complex128) {
+	for k, x := range src {
+		src[k] = cmplx.Conj(x)
+	}
+
+	p.forward(dst, src, scratch)
+
+	scale := 1 / float64(p.n)
+	for k, x := range dst {
+		dst[k] = complex(real(x)*scale, -imag(x)*scale)
+	}
+}
+
+// cooleyTukey writes the transform of the elements of src spaced by
+// the given stride into dst, recursively splitting it into as many
+// interleaved transforms as the first of the given radices.
+func (p *plan) cooleyTukey(dst, src []complex128, stride int, factors []int, scratch []complex128) {
+	n := len(dst)
+	if n == 1 {
+		dst[0] = src[0]
+		return
+	}
+
+	f := factors[0]
+	m := n / f
+
+	for r := 0; r < f; r++ {
+		p.cooleyTukey(dst[r*m:(r+1)*m], src[r*stride:], stride*f, factors[1:], scratch)
+	}
+
+	w := p.twiddles
+
+	switch f {
+	case 2:
+		for k := 0; k < m; k++ {
+			a, b := dst[k], dst[m+k]*w[k*stride]
+			dst[k], dst[m+k] = a+b, a-b
+		}
+	case 4:
+		for k := 0; k < m; k++ {
+			a := dst[k]
+			b := dst[m+k] * w[k*stride]
+			c := dst[2*m+k] * w[2*k*stride]
+			d := dst[3*m+k] * w[3*k*stride]
+
+			t0, t1 := a+c, a-c
+			t2, t3 := b+d, b-d
+			t3 = complex(imag(t3), -real(t3)) // multiplied by -i
+
+			dst[k], dst[2*m+k] = t0+t2, t0-t2
+			dst[m+k], dst[3*m+k] = t1+t3, t1-t3
+		}
+	default:
+		y := scratch[:f]
+		step := p.n / f // the f-th roots of unity are spaced by n/f
+
+		for k := 0; k < m; k++ {
+			for r := range y {
+				y[r] = dst[r*m+k] * w[r*k*stride]
+			}
+
+			for q := 0; q < f; q++ {
+				var s complex128
+				for r, x := range y {
+					s += x * w[(r*q%f)*step]
+				}
+				dst[q*m+k] = s
+			}
+		}
+	}
+}
+
+// bluestein writes the transform of src into dst, expressing
+// it as a convolution computed by transforms of length m.
+func (p *plan) bluestein(dst, src, scratch []complex128) {
+	m := p.inner.n
+	a, b, scratch := scratch[:m], scratch[m:2*m], scratch[2*m:]
+
+	for k := range a {
+		a[k] = 0
+	}
+	for k, x := range src[:p.n] {
+		a[k] = x * p.chirp[k]
+	}
+
+	p.inner.forward(b, a, scratch)
+	for k := range b {
+		b[k] = cmplx.Conj(b[k] * p.kernel[k])
+	}
+
+	p.inner.forward(a, b, scratch)
+	for k := range dst {
+		dst[k] = p.chirp[k] * cmplx.Conj(a[k])
+	}
+}
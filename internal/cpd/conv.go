@@ -0,0 +1,205 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import "github.com/lordlarker/nune"
+
+// minIm2col is the minimum number of elements held by the kernels
+// of a group for a convolution to be lowered to a matrix product.
+const minIm2col = 1 << 13
+
+// A ConvGeometry describes a convolution over one or more spatial axes.
+// The output element at the spatial position o is computed from the input
+// elements at the positions o*Stride + k*Dilation - Pad, for every position
+// k of the kernel, positions falling outside of the input counting as zeros.
+type ConvGeometry struct {
+	In, Kernel, Out       []int // the spatial shapes of the input, kernel and output
+	Stride, Dilation, Pad []int // the stride, dilation and leading padding of each axis
+}
+
+// coords returns, for each axis, the input coordinates read by each pair
+// of output and kernel coordinates o and k at index o*Kernel[axis]+k,
+// or -1 for coordinates falling outside of the input.
+func (g ConvGeometry) coords() [][]int {
+	coords := make([][]int, len(g.In))
+
+	for a := range coords {
+		coords[a] = make([]int, g.Out[a]*g.Kernel[a])
+
+		for o := 0; o < g.Out[a]; o++ {
+			for k := 0; k < g.Kernel[a]; k++ {
+				c := o*g.Stride[a] + k*g.Dilation[a] - g.Pad[a]
+				if c < 0 || c >= g.In[a] {
+					c = -1
+				}
+				coords[a][o*g.Kernel[a]+k] = c
+			}
+		}
+	}
+
+	return coords
+}
+
+// Conv computes the cross-correlation of the batch inputs of cin channels
+// held in src with the cout kernels held in ker, and writes it into dst.
+// Channels are split into groups, the kernels of each group only seeing
+// the input channels of the same group.
+//
+// The buffers are contiguous, of shapes (batch, cin, In...) for src,
+// (cout, cin/groups, Kernel...) for ker and (batch, cout, Out...) for dst.
+// The convolution is either computed directly, or lowered to a matrix
+// product through the im2col transformation when the kernels of a group
+// hold enough elements for the blocked product to pay off.
+func Conv[T nune.Number](batch, groups, cin, cout int, g ConvGeometry, src, ker, dst []T) {
+	if cout/groups*cin/groups*numel(g.Kernel) >= minIm2col {
+		convIm2col(batch, groups, cin, cout, g, src, ker, dst)
+	} else {
+		convDirect(batch, groups, cin, cout, g, src, ker, dst)
+	}
+}
+
+// convDirect computes the convolution row by row, each row of the output
+// along the last axis accumulating, for each position of the kernels,
+// the matching input row scaled by the kernel's element. The rows
+// of the output are computed concurrently.
+func convDirect[T nune.Number](batch, groups, cin, cout int, g ConvGeometry, src, ker, dst []T) {
+	d := len(g.In) - 1
+	coords := g.coords()
+	strides := Strides(g.In)
+
+	cg, og := cin/groups, cout/groups
+	k, in := numel(g.Kernel), numel(g.In)
+	width, kw := g.Out[d], g.Kernel[d]
+	stride, dilation, pad := g.Stride[d], g.Dilation[d], g.Pad[d]
+
+	// the range of output positions along the last axis
+	// reading within the input, for each kernel position
+	lo, hi := make([]int, kw), make([]int, kw)
+	for j := range lo {
+		for o := 0; o < width; o++ {
+			if coords[d][o*kw+j] >= 0 {
+				if hi[j] == 0 {
+					lo[j] = o
+				}
+				hi[j] = o + 1
+			}
+		}
+	}
+
+	rows := numel(g.Out[:d])
+
+	Parallel(batch*cout*rows, 1+minChunk/(width*cg*k), func(start, end int) {
+		out := newIter(g.Out[:d], start, nil)
+		kern := newIter(g.Kernel[:d], 0, nil)
+
+		for i := start; i < end; i++ {
+			oc, n := i/rows%cout, i/rows/cout
+			base := (n*cin + oc/og*cg) * in
+
+			acc := dst[i*width : (i+1)*width]
+			for o := range acc {
+				acc[o] = 0
+			}
+
+			for r := 0; r < k/kw; r++ {
+				row := 0
+				for a, o := range out.index {
+					c := coords[a][o*g.Kernel[a]+kern.index[a]]
+					if c < 0 {
+						row = -1
+						break
+					}
+					row += c * strides[a]
+				}
+
+				if row >= 0 {
+					for ic := 0; ic < cg; ic++ {
+						w := ker[(oc*cg+ic)*k+r*kw:]
+						x := src[base+ic*in+row:]
+
+						for j := 0; j < kw; j++ {
+							shift := j*dilation - pad
+							for o := lo[j]; o < hi[j]; o++ {
+								acc[o] += w[j] * x[o*stride+shift]
+							}
+						}
+					}
+				}
+
+				kern.next()
+			}
+
+			out.next()
+		}
+	})
+}
+
+// colTile is the maximum number of output positions whose
+// input elements are gathered at once by convIm2col.
+const colTile = 1 << 12
+
+// convIm2col computes the convolution as matrix products: for each input
+// and group, the input elements read by the kernels at up to colTile
+// output positions are gathered into a (cin/groups * kernel size) ×
+// (positions) matrix, by which the matrix of the group's kernels is
+// multiplied.
+func convIm2col[T nune.Number](batch, groups, cin, cout int, g ConvGeometry, src, ker, dst []T) {
+	coords := g.coords()
+	strides := Strides(g.In)
+
+	cg, og := cin/groups, cout/groups
+	k, l, in := numel(g.Kernel), numel(g.Out), numel(g.In)
+	rows := cg * k
+
+	col := make([]T, rows*min(l, colTile))
+
+	for n := 0; n < batch; n++ {
+		for grp := 0; grp < groups; grp++ {
+			base := (n*cin + grp*cg) * in
+
+			for j0 := 0; j0 < l; j0 += colTile {
+				cols := min(colTile, l-j0)
+
+				Parallel(rows, 1+minChunk/cols, func(start, end int) {
+					kern := newIter(g.Kernel, start%k, nil)
+					out := newIter(g.Out, j0, nil)
+
+					for r := start; r < end; r++ {
+						ic := r / k
+						out.seek(j0, nil)
+
+						for j := 0; j < cols; j++ {
+							pos := 0
+							for a, o := range out.index {
+								c := coords[a][o*g.Kernel[a]+kern.index[a]]
+								if c < 0 {
+									pos = -1
+									break
+								}
+								pos += c * strides[a]
+							}
+
+							if pos >= 0 {
+								col[r*cols+j] = src[base+ic*in+pos]
+							} else {
+								col[r*cols+j] = 0
+							}
+
+							out.next()
+						}
+
+						kern.next()
+					}
+				})
+
+				a := Span[T]{Buf: ker, Strides: []int{rows, 1}, Offset: grp * og * rows}
+				b := Flat(col[:rows*cols], []int{rows, cols})
+				c := Span[T]{Buf: dst, Strides: []int{l, 1}, Offset: (n*cout+grp*og)*l + j0}
+
+				MatMul(nil, og, cols, rows, a, b, c)
+			}
+		}
+	}
+}
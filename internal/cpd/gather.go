@@ -0,0 +1,82 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import "github.com/lordlarker/nune"
+
+// Gather writes into the dst span, at each position of the given shape,
+// the element of the src span at the same position, except along the
+// given axis, where it is at the position held by the idx span.
+func Gather[T nune.Number](shape []int, axis int, idx Span[int], src, dst Span[T]) {
+	step := src.Strides[axis]
+
+	lines(shape, axis, idx, src, dst, func(ip, is, sp, _, dp, ds, n int) {
+		for j := 0; j < n; j++ {
+			dst.Buf[dp+j*ds] = src.Buf[sp+idx.Buf[ip+j*is]*step]
+		}
+	})
+}
+
+// Scatter writes each element of the src span, at the positions of the
+// given shape, into the dst span at the same position, except along the
+// given axis, where it is at the position held by the idx span. If add is
+// true, the elements are added to those of the dst span instead.
+//
+// The elements written to the same position are those of a single line
+// along the axis, which are processed in order: the last one overwrites
+// the others, or they are all added up.
+func Scatter[T nune.Number](shape []int, axis int, idx Span[int], src, dst Span[T], add bool) {
+	step := dst.Strides[axis]
+
+	lines(shape, axis, idx, src, dst, func(ip, is, sp, ss, dp, _, n int) {
+		for j := 0; j < n; j++ {
+			pos := dp + idx.Buf[ip+j*is]*step
+			if add {
+				dst.Buf[pos] += src.Buf[sp+j*ss]
+			} else {
+				dst.Buf[pos] = src.Buf[sp+j*ss]
+			}
+		}
+	})
+}
+
+// lines concurrently calls f over each line of the given shape along the
+// given axis, with the position of the line's first element and the stride
+// between its elements in each of the spans, and the line's length.
+func lines[T nune.Number](shape []int, axis int, idx Span[int], a, b Span[T], f func(ip, is, ap, as, bp, bs, n int)) {
+	n := shape[axis]
+
+	offsets := []int{idx.Offset, a.Offset, b.Offset}
+	strides := [][]int{idx.Strides, a.Strides, b.Strides}
+
+	eachLine(shape, axis, offsets, strides, func(pos []int) {
+		f(pos[0], idx.Strides[axis], pos[1], a.Strides[axis], pos[2], b.Strides[axis], n)
+	})
+}
+
+// eachLine concurrently calls f over each line of the given shape along
+// the given axis, with the position of the line's first element through
+// each of the given offsets and strides.
+func eachLine(shape []int, axis int, offsets []int, strides [][]int, f func(pos []int)) {
+	outer := dropAxis(shape, axis)
+	inner := make([][]int, len(strides))
+	for i, s := range strides {
+		inner[i] = dropAxis(s, axis)
+	}
+
+	Parallel(numel(outer), 1+minChunk/shape[axis], func(start, end int) {
+		it := newIter(outer, start, offsets, inner...)
+
+		for i := start; i < end; i++ {
+			f(it.pos)
+			it.next()
+		}
+	})
+}
+
+// dropAxis returns a copy of the given shape or strides without the given axis.
+func dropAxis(s []int, axis int) []int {
+	return append(append([]int{}, s[:axis]...), s[axis+1:]...)
+}
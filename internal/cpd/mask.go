@@ -0,0 +1,138 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import (
+	"sync/atomic"
+
+	"github.com/lordlarker/nune"
+)
+
+// Compare applies the predicate f over each pair of elements of the
+// s1 and s2 spans and writes the results, as 1 for true and 0 for false,
+// at the same positions of the res span.
+func Compare[T nune.Number](shape []int, s1, s2 Span[T], res Span[uint8], f func(T, T) bool) {
+	n := numel(shape)
+
+	if contiguous(shape, s1, s2) && Contiguous(shape, res.Strides) {
+		b1 := s1.Buf[s1.Offset : s1.Offset+n]
+		b2 := s2.Buf[s2.Offset : s2.Offset+n]
+		r := res.Buf[res.Offset : res.Offset+n]
+
+		Parallel(n, minChunk, func(start, end int) {
+			for i := start; i < end; i++ {
+				r[i] = boolToByte(f(b1[i], b2[i]))
+			}
+		})
+
+		return
+	}
+
+	Parallel(n, minChunk, func(start, end int) {
+		it := newIter(shape, start, []int{s1.Offset, s2.Offset, res.Offset}, s1.Strides, s2.Strides, res.Strides)
+		for i := start; i < end; i++ {
+			res.Buf[it.pos[2]] = boolToByte(f(s1.Buf[it.pos[0]], s2.Buf[it.pos[1]]))
+			it.next()
+		}
+	})
+}
+
+// Select writes into the res span the elements of the a span at the
+// positions where the mask span is non-zero, and those of the b span
+// elsewhere.
+func Select[T nune.Number](shape []int, mask Span[uint8], a, b, res Span[T]) {
+	n := numel(shape)
+
+	Parallel(n, minChunk, func(start, end int) {
+		it := newIter(shape, start, []int{mask.Offset, a.Offset, b.Offset, res.Offset}, mask.Strides, a.Strides, b.Strides, res.Strides)
+		for i := start; i < end; i++ {
+			if mask.Buf[it.pos[0]] != 0 {
+				res.Buf[it.pos[3]] = a.Buf[it.pos[1]]
+			} else {
+				res.Buf[it.pos[3]] = b.Buf[it.pos[2]]
+			}
+			it.next()
+		}
+	})
+}
+
+// Compact returns, in order, the elements of the src buffer at the
+// positions where the mask buffer is non-zero. The buffers are split
+// into chunks whose selected elements are counted, and then copied
+// at their final position, concurrently.
+func Compact[T nune.Number](src []T, mask []uint8) []T {
+	n := len(src)
+
+	chunks := nCPU
+	if n/minChunk < chunks {
+		chunks = n/minChunk + 1
+	}
+
+	bounds := func(c int) (int, int) {
+		return c * n / chunks, (c + 1) * n / chunks
+	}
+
+	offsets := make([]int, chunks+1)
+	Parallel(chunks, 1, func(first, last int) {
+		for c := first; c < last; c++ {
+			start, end := bounds(c)
+			for _, m := range mask[start:end] {
+				if m != 0 {
+					offsets[c+1]++
+				}
+			}
+		}
+	})
+
+	for c := 0; c < chunks; c++ {
+		offsets[c+1] += offsets[c]
+	}
+
+	res := make([]T, offsets[chunks])
+	Parallel(chunks, 1, func(first, last int) {
+		for c := first; c < last; c++ {
+			start, end := bounds(c)
+
+			j := offsets[c]
+			for i := start; i < end; i++ {
+				if mask[i] != 0 {
+					res[j] = src[i]
+					j++
+				}
+			}
+		}
+	})
+
+	return res
+}
+
+// CountNonzero returns the number of non-zero elements of the src span.
+func CountNonzero[T nune.Number](shape []int, src Span[T]) int {
+	var count int64
+
+	Parallel(numel(shape), minChunk, func(start, end int) {
+		it := newIter(shape, start, []int{src.Offset}, src.Strides)
+
+		var c int64
+		for i := start; i < end; i++ {
+			if src.Buf[it.pos[0]] != 0 {
+				c++
+			}
+			it.next()
+		}
+
+		atomic.AddInt64(&count, c)
+	})
+
+	return int(count)
+}
+
+func boolToByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
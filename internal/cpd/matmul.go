@@ -0,0 +1,100 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import "github.com/lordlarker/nune"
+
+// Dimensions of the blocks the matrices are tiled into,
+// chosen so that a block of each operand fits in the L2 cache.
+const (
+	blockM = 64
+	blockN = 128
+	blockK = 256
+)
+
+// MatMul computes, for every position of the batch shape, the product
+// of the m×k matrix of the a span and the k×n matrix of the b span,
+// and writes it into the m×n matrix of the c span.
+// The spans' strides hold the batch axes first, followed by
+// the two axes of their matrices.
+//
+// The matrices are tiled into blocks which are packed into contiguous
+// buffers, and the blocks of the output are computed concurrently. The
+// buffers are no larger than the matrices, which keeps small products
+// cheap.
+func MatMul[T nune.Number](batch []int, m, n, k int, a, b, c Span[T]) {
+	nb := len(batch)
+	rowBlocks := (m + blockM - 1) / blockM
+	colBlocks := (n + blockN - 1) / blockN
+	perBatch := rowBlocks * colBlocks
+
+	as, bs, cs := a.Strides[nb:], b.Strides[nb:], c.Strides[nb:]
+	bm, bn, bk := min(blockM, m), min(blockN, n), min(blockK, k)
+
+	Parallel(numel(batch)*perBatch, 1, func(start, end int) {
+		pa := make([]T, bm*bk)
+		pb := make([]T, bk*bn)
+		acc := make([]T, bm*bn)
+
+		it := newIter(batch, 0, []int{a.Offset, b.Offset, c.Offset}, a.Strides[:nb], b.Strides[:nb], c.Strides[:nb])
+
+		for task := start; task < end; task++ {
+			it.seek(task/perBatch, []int{a.Offset, b.Offset, c.Offset})
+			ao, bo, co := it.pos[0], it.pos[1], it.pos[2]
+
+			i0 := (task % perBatch) / colBlocks * blockM
+			j0 := (task % perBatch) % colBlocks * blockN
+			mm, nn := min(blockM, m-i0), min(blockN, n-j0)
+
+			for x := range acc[:mm*nn] {
+				acc[x] = 0
+			}
+
+			for p0 := 0; p0 < k; p0 += blockK {
+				kk := min(blockK, k-p0)
+
+				for i := 0; i < mm; i++ {
+					row := ao + (i0+i)*as[0] + p0*as[1]
+					for p := 0; p < kk; p++ {
+						pa[i*kk+p] = a.Buf[row+p*as[1]]
+					}
+				}
+
+				for p := 0; p < kk; p++ {
+					row := bo + (p0+p)*bs[0] + j0*bs[1]
+					for j := 0; j < nn; j++ {
+						pb[p*nn+j] = b.Buf[row+j*bs[1]]
+					}
+				}
+
+				for i := 0; i < mm; i++ {
+					accRow := acc[i*nn : (i+1)*nn]
+					for p := 0; p < kk; p++ {
+						x := pa[i*kk+p]
+						bRow := pb[p*nn : (p+1)*nn]
+						for j := range accRow {
+							accRow[j] += x * bRow[j]
+						}
+					}
+				}
+			}
+
+			for i := 0; i < mm; i++ {
+				row := co + (i0+i)*cs[0] + j0*cs[1]
+				for j := 0; j < nn; j++ {
+					c.Buf[row+j*cs[1]] = acc[i*nn+j]
+				}
+			}
+		}
+	})
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
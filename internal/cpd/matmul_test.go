@@ -0,0 +1,34 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestMatMulScratch(t *testing.T) {
+	a, b, c := []float64{1, 2, 3, 4}, []float64{5, 6, 7, 8}, make([]float64, 4)
+	shape := []int{2, 2}
+
+	const runs = 100
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := 0; i < runs; i++ {
+		MatMul(nil, 2, 2, 2, Flat(a, shape), Flat(b, shape), Flat(c, shape))
+	}
+	runtime.ReadMemStats(&after)
+
+	// a 2×2 product only needs a few small buffers,
+	// rather than blocks sized for large matrices
+	if per := (after.TotalAlloc - before.TotalAlloc) / runs; per > 2048 {
+		t.Errorf("got %d bytes allocated per product, want at most 2048", per)
+	}
+
+	if want := []float64{19, 22, 43, 50}; c[0] != want[0] || c[1] != want[1] || c[2] != want[2] || c[3] != want[3] {
+		t.Errorf("got %v, want %v", c, want)
+	}
+}
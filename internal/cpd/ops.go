@@ -14,60 +14,76 @@ import (
 
 var nCPU = runtime.NumCPU()
 
-func Pointwise[T nune.Numeric](buf []T, f func(T) T) {
-	var wg sync.WaitGroup
-
-	chunk := int(math.Floor(float64(len(buf)) / float64(nCPU)))
-
-	for i := 0; i < len(buf); i += chunk {
-		var end int
-		if i+chunk > len(buf) {
-			end = len(buf)
-		} else {
-			end = i + chunk
-		}
-
-		go func(s []T) {
-			for i := 0; i < len(s); i++ {
-				buf[i] = f(buf[i])
+// Pointwise applies f over each element of the src span
+// and writes the results at the same positions of the dst span.
+func Pointwise[T nune.Number](shape []int, src, dst Span[T], f func(T) T) {
+	n := numel(shape)
+
+	if contiguous(shape, src, dst) {
+		s := src.Buf[src.Offset : src.Offset+n]
+		d := dst.Buf[dst.Offset : dst.Offset+n]
+
+		Parallel(n, minChunk, func(start, end int) {
+			for i := start; i < end; i++ {
+				d[i] = f(s[i])
 			}
+		})
 
-			wg.Done()
-		}(buf[i:end])
-
-		wg.Add(1)
+		return
 	}
-	wg.Wait()
-}
 
-func Op[T nune.Numeric](buf1, buf2, res []T, f func(T, T) T) {
-	var wg sync.WaitGroup
+	Parallel(n, minChunk, func(start, end int) {
+		it := newIter(shape, start, []int{src.Offset, dst.Offset}, src.Strides, dst.Strides)
+		for i := start; i < end; i++ {
+			dst.Buf[it.pos[1]] = f(src.Buf[it.pos[0]])
+			it.next()
+		}
+	})
+}
 
-	chunk := int(math.Floor(float64(len(res)) / float64(nCPU)))
+// Op applies f over each pair of elements of the s1 and s2 spans
+// and writes the results at the same positions of the res span.
+func Op[T nune.Number](shape []int, s1, s2, res Span[T], f func(T, T) T) {
+	n := numel(shape)
 
-	for i := 0; i < len(res); i += chunk {
-		var end int
-		if i+chunk > len(res) {
-			end = len(res)
-		} else {
-			end = i + chunk
-		}
+	if contiguous(shape, s1, s2, res) {
+		b1 := s1.Buf[s1.Offset : s1.Offset+n]
+		b2 := s2.Buf[s2.Offset : s2.Offset+n]
+		r := res.Buf[res.Offset : res.Offset+n]
 
-		go func(s1, s2, s3 []T) {
-			for i := 0; i < len(s3); i++ {
-				s3[i] = f(s1[i], s2[i])
+		Parallel(n, minChunk, func(start, end int) {
+			for i := start; i < end; i++ {
+				r[i] = f(b1[i], b2[i])
 			}
+		})
 
-			wg.Done()
-		}(buf1[i:end], buf2[i:end], res[i:end])
+		return
+	}
 
-		wg.Add(1)
+	Parallel(n, minChunk, func(start, end int) {
+		it := newIter(shape, start, []int{s1.Offset, s2.Offset, res.Offset}, s1.Strides, s2.Strides, res.Strides)
+		for i := start; i < end; i++ {
+			res.Buf[it.pos[2]] = f(s1.Buf[it.pos[0]], s2.Buf[it.pos[1]])
+			it.next()
+		}
+	})
+}
+
+// Copy copies the elements of the src span into the dst span.
+func Copy[T nune.Number](shape []int, src, dst Span[T]) {
+	if contiguous(shape, src, dst) {
+		n := numel(shape)
+		copy(dst.Buf[dst.Offset:dst.Offset+n], src.Buf[src.Offset:src.Offset+n])
+		return
 	}
-	wg.Wait()
+
+	Pointwise(shape, src, dst, func(x T) T {
+		return x
+	})
 }
 
-func Reduct[T nune.Numeric](buf []T, f func([]T) T) T {
-	nChunks := int(math.Ceil(float64(len(buf))/float64(nCPU)))
+func Reduct[T nune.Number](buf []T, f func([]T) T) T {
+	nChunks := int(math.Ceil(float64(len(buf)) / float64(nCPU)))
 
 	var wg sync.WaitGroup
 
@@ -75,7 +91,7 @@ func Reduct[T nune.Numeric](buf []T, f func([]T) T) T {
 	ch := make(chan T, nChunks)
 
 	for i := 0; i < nChunks; i++ {
-    	min := (i * len(buf) / nChunks)
+		min := (i * len(buf) / nChunks)
 		max := ((i + 1) * len(buf)) / nChunks
 
 		wg.Add(1)
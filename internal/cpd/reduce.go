@@ -0,0 +1,71 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import "github.com/lordlarker/nune"
+
+// Reduce reduces the src span, whose shape is outShape followed by
+// redShape, over its trailing redShape axes, and writes the results
+// at the positions of the dst span of shape outShape.
+// The reduction folds f over the elements, starting from init if it
+// is provided, or from the first element otherwise.
+func Reduce[T nune.Number](outShape, redShape []int, src, dst Span[T], f func(T, T) T, init ...T) {
+	k, m := len(outShape), numel(redShape)
+
+	Parallel(numel(outShape), minChunk/m, func(start, end int) {
+		it := newIter(outShape, start, []int{src.Offset, dst.Offset}, src.Strides[:k], dst.Strides)
+		in := newIter(redShape, 0, []int{0}, src.Strides[k:])
+
+		for i := start; i < end; i++ {
+			in.seek(0, it.pos[:1])
+
+			var acc T
+			j := 0
+			if len(init) == 1 {
+				acc = init[0]
+			} else {
+				acc = src.Buf[in.pos[0]]
+				in.next()
+				j++
+			}
+
+			for ; j < m; j++ {
+				acc = f(acc, src.Buf[in.pos[0]])
+				in.next()
+			}
+
+			dst.Buf[it.pos[1]] = acc
+			it.next()
+		}
+	})
+}
+
+// ArgReduce walks the src span, whose shape is outShape followed by
+// redShape, over its trailing redShape axes, and writes at the positions
+// of the dst span of shape outShape the row-major index within redShape
+// of the first element for which better holds against all others.
+func ArgReduce[T nune.Number](outShape, redShape []int, src Span[T], dst Span[int], better func(x, y T) bool) {
+	k, m := len(outShape), numel(redShape)
+
+	Parallel(numel(outShape), minChunk/m, func(start, end int) {
+		it := newIter(outShape, start, []int{src.Offset, dst.Offset}, src.Strides[:k], dst.Strides)
+		in := newIter(redShape, 0, []int{0}, src.Strides[k:])
+
+		for i := start; i < end; i++ {
+			in.seek(0, it.pos[:1])
+
+			best, arg := src.Buf[in.pos[0]], 0
+			for j := 1; j < m; j++ {
+				in.next()
+				if x := src.Buf[in.pos[0]]; better(x, best) {
+					best, arg = x, j
+				}
+			}
+
+			dst.Buf[it.pos[1]] = arg
+			it.next()
+		}
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import "github.com/lordlarker/nune"
+
+// minScan is the length from which a line is scanned
+// in concurrent chunks rather than by a single goroutine.
+const minScan = 1 << 15
+
+// Scan writes into the dst span the inclusive scans of the lines of
+// the src span along the given axis under the associative operator f:
+// the j-th element of a line of the dst span is the fold of f over
+// the first j+1 elements of the line of the src span.
+//
+// Lines are scanned concurrently, unless there are fewer lines than CPUs
+// and they are long, in which case each one is split into chunks which
+// are scanned concurrently, and then combined with the fold of the
+// chunks before them, for about twice the work of a sequential scan.
+func Scan[T nune.Number](shape []int, axis int, src, dst Span[T], f func(T, T) T) {
+	n := shape[axis]
+	ss, ds := src.Strides[axis], dst.Strides[axis]
+
+	offsets := []int{src.Offset, dst.Offset}
+	strides := [][]int{src.Strides, dst.Strides}
+
+	if n < minScan || numel(shape)/n >= nCPU {
+		eachLine(shape, axis, offsets, strides, func(pos []int) {
+			scan(src.Buf, pos[0], ss, dst.Buf, pos[1], ds, n, f)
+		})
+
+		return
+	}
+
+	outer := dropAxis(shape, axis)
+	it := newIter(outer, 0, offsets, dropAxis(src.Strides, axis), dropAxis(dst.Strides, axis))
+	for i := numel(outer); i > 0; i-- {
+		scanChunks(src.Buf, it.pos[0], ss, dst.Buf, it.pos[1], ds, n, f)
+		it.next()
+	}
+}
+
+// scanChunks scans a line of n elements of the src buffer, starting at
+// position sp with a stride of ss, into the dst buffer, starting at
+// position dp with a stride of ds, in concurrent chunks.
+func scanChunks[T nune.Number](src []T, sp, ss int, dst []T, dp, ds, n int, f func(T, T) T) {
+	chunks := nCPU
+	if n/minChunk < chunks {
+		chunks = n / minChunk
+	}
+
+	bounds := func(c int) (int, int) {
+		return c * n / chunks, (c + 1) * n / chunks
+	}
+
+	// scan each chunk on its own, and keep its fold
+	carries := make([]T, chunks)
+	Parallel(chunks, 1, func(first, last int) {
+		for c := first; c < last; c++ {
+			start, end := bounds(c)
+			scan(src, sp+start*ss, ss, dst, dp+start*ds, ds, end-start, f)
+			carries[c] = dst[dp+(end-1)*ds]
+		}
+	})
+
+	// fold the chunks' folds into those of their prefixes
+	for c := 1; c < chunks; c++ {
+		carries[c] = f(carries[c-1], carries[c])
+	}
+
+	// combine each chunk but the first with the fold of its prefix
+	Parallel(chunks-1, 1, func(first, last int) {
+		for c := first + 1; c <= last; c++ {
+			start, end := bounds(c)
+			for j := start; j < end; j++ {
+				p := dp + j*ds
+				dst[p] = f(carries[c-1], dst[p])
+			}
+		}
+	})
+}
+
+// scan sequentially scans a line of n elements of the src buffer,
+// starting at position sp with a stride of ss, into the dst buffer,
+// starting at position dp with a stride of ds.
+func scan[T nune.Number](src []T, sp, ss int, dst []T, dp, ds, n int, f func(T, T) T) {
+	acc := src[sp]
+	dst[dp] = acc
+
+	for j := 1; j < n; j++ {
+		acc = f(acc, src[sp+j*ss])
+		dst[dp+j*ds] = acc
+	}
+}
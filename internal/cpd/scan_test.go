@@ -0,0 +1,50 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import "testing"
+
+func TestScanChunks(t *testing.T) {
+	defer func(n int) { nCPU = n }(nCPU)
+	nCPU = 4
+
+	add := func(x, y int) int { return x + y }
+
+	tests := []struct {
+		name  string
+		shape []int
+		axis  int
+	}{
+		{"single line", []int{minScan + 123}, 0},
+		{"two lines", []int{2, minScan * 3}, 1},
+		{"leading axis", []int{minScan + 7, 3}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := numel(tt.shape)
+			src := make([]int, n)
+			for i := range src {
+				src[i] = i%7 - 3
+			}
+
+			dst := make([]int, n)
+			Scan(tt.shape, tt.axis, Flat(src, tt.shape), Flat(dst, tt.shape), add)
+
+			// the same scan, sequentially
+			strides := Strides(tt.shape)
+			s, m := strides[tt.axis], tt.shape[tt.axis]
+			for i := 0; i < n; i++ {
+				if i/s%m != 0 {
+					src[i] += src[i-s]
+				}
+
+				if dst[i] != src[i] {
+					t.Fatalf("element %d: got %d, want %d", i, dst[i], src[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import (
+	"sort"
+
+	"github.com/lordlarker/nune"
+)
+
+// ArgSort writes into the dst span, along each line of the given shape
+// along the given axis, the indices which sort the line of the src span
+// in ascending or descending order. The sort is stable: equal elements
+// keep their order. NaNs are ordered after every other element, as if
+// they were the greatest.
+func ArgSort[T nune.Numeric](shape []int, axis int, src Span[T], dst Span[int], descending bool) {
+	n := shape[axis]
+	ss, ds := src.Strides[axis], dst.Strides[axis]
+
+	eachLine(shape, axis, []int{src.Offset, dst.Offset}, [][]int{src.Strides, dst.Strides}, func(pos []int) {
+		s := argSorter[T]{keys: make([]T, n), idx: make([]int, n), desc: descending}
+		for j := range s.keys {
+			s.keys[j] = src.Buf[pos[0]+j*ss]
+			s.idx[j] = j
+		}
+
+		sort.Sort(s)
+
+		for j, i := range s.idx {
+			dst.Buf[pos[1]+j*ds] = i
+		}
+	})
+}
+
+// SearchSorted writes into the dst span, at each position of the given
+// shape, the index at which the element of the vals span would be inserted
+// in the sorted line of m elements of the seq span, along the last axis,
+// to keep it sorted: before the elements equal to it, or after them if
+// right is true. The seq span's stride along the last axis is the one
+// between the elements of its lines.
+func SearchSorted[T nune.Numeric](shape []int, seq Span[T], m int, vals Span[T], dst Span[int], right bool) {
+	axis := len(shape) - 1
+	qs, vs, ds := seq.Strides[axis], vals.Strides[axis], dst.Strides[axis]
+
+	offsets := []int{seq.Offset, vals.Offset, dst.Offset}
+	strides := [][]int{seq.Strides, vals.Strides, dst.Strides}
+
+	eachLine(shape, axis, offsets, strides, func(pos []int) {
+		for j := 0; j < shape[axis]; j++ {
+			v := vals.Buf[pos[1]+j*vs]
+
+			dst.Buf[pos[2]+j*ds] = sort.Search(m, func(k int) bool {
+				x := seq.Buf[pos[0]+k*qs]
+				if right {
+					return less(v, x)
+				}
+
+				return !less(x, v)
+			})
+		}
+	})
+}
+
+// less returns whether or not x is ordered before y,
+// NaNs being ordered after every other value.
+func less[T nune.Numeric](x, y T) bool {
+	return x < y || y != y && x == x
+}
+
+// argSorter sorts keys along with their original indices,
+// which break ties such that the sort is stable.
+type argSorter[T nune.Numeric] struct {
+	keys []T
+	idx  []int
+	desc bool
+}
+
+func (s argSorter[T]) Len() int {
+	return len(s.keys)
+}
+
+func (s argSorter[T]) Less(i, j int) bool {
+	x, y := s.keys[i], s.keys[j]
+	if s.desc {
+		x, y = y, x
+	}
+
+	switch {
+	case less(x, y):
+		return true
+	case less(y, x):
+		return false
+	default:
+		return s.idx[i] < s.idx[j]
+	}
+}
+
+func (s argSorter[T]) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.idx[i], s.idx[j] = s.idx[j], s.idx[i]
+}
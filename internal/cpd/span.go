@@ -0,0 +1,171 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpd
+
+import (
+	"sync"
+
+	"github.com/lordlarker/nune"
+)
+
+// minChunk is the minimum number of elements
+// that are worth dispatching to their own goroutine.
+const minChunk = 1 << 11
+
+// A Span is a strided view over a flat buffer.
+// The element at the multi-index (i0, i1, ..., in)
+// is found at Offset + i0*Strides[0] + ... + in*Strides[n].
+type Span[T nune.Number] struct {
+	Buf     []T
+	Strides []int
+	Offset  int
+}
+
+// Flat returns a Span over a contiguous buffer of the given shape.
+func Flat[T nune.Number](buf []T, shape []int) Span[T] {
+	return Span[T]{
+		Buf:     buf,
+		Strides: Strides(shape),
+	}
+}
+
+// Strides returns the row-major strides of a contiguous buffer
+// of the given shape.
+func Strides(shape []int) []int {
+	strides := make([]int, len(shape))
+
+	s := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = s
+		s *= shape[i]
+	}
+
+	return strides
+}
+
+// Contiguous returns whether or not the given strides describe
+// a row-major contiguous layout of the given shape.
+// Axes with a single dimension are ignored as they are never walked.
+func Contiguous(shape, strides []int) bool {
+	s := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] != 1 && strides[i] != s {
+			return false
+		}
+		s *= shape[i]
+	}
+
+	return true
+}
+
+// numel returns the number of elements of the given shape.
+func numel(shape []int) int {
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+
+	return n
+}
+
+// contiguous returns whether or not all the spans are contiguous
+// for the given shape.
+func contiguous[T nune.Number](shape []int, spans ...Span[T]) bool {
+	for _, s := range spans {
+		if !Contiguous(shape, s.Strides) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Parallel splits the interval [0, n) into contiguous chunks of
+// at least grain elements, and concurrently calls f over each one of them.
+func Parallel(n, grain int, f func(start, end int)) {
+	if grain < 1 {
+		grain = 1
+	}
+
+	chunks := nCPU
+	if n/grain < chunks {
+		chunks = n / grain
+	}
+
+	if chunks <= 1 {
+		if n > 0 {
+			f(0, n)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(chunks)
+	for i := 0; i < chunks; i++ {
+		go func(start, end int) {
+			f(start, end)
+			wg.Done()
+		}(i*n/chunks, (i+1)*n/chunks)
+	}
+	wg.Wait()
+}
+
+// An iter walks the positions of one or more strided spans
+// sharing the same shape, in row-major order.
+type iter struct {
+	shape   []int
+	strides [][]int
+	index   []int
+	pos     []int
+}
+
+// newIter returns an iter positioned at the start-th element
+// of the given shape.
+func newIter(shape []int, start int, offsets []int, strides ...[]int) *iter {
+	it := &iter{
+		shape:   shape,
+		strides: strides,
+		index:   make([]int, len(shape)),
+		pos:     make([]int, len(offsets)),
+	}
+	it.seek(start, offsets)
+
+	return it
+}
+
+// seek positions the iter at the start-th element of its shape,
+// for spans starting at the given offsets.
+func (it *iter) seek(start int, offsets []int) {
+	copy(it.pos, offsets)
+
+	for d := len(it.shape) - 1; d >= 0; d-- {
+		it.index[d] = start % it.shape[d]
+		start /= it.shape[d]
+
+		for k := range it.pos {
+			it.pos[k] += it.index[d] * it.strides[k][d]
+		}
+	}
+}
+
+// next advances the iter to the next element.
+func (it *iter) next() {
+	for d := len(it.shape) - 1; d >= 0; d-- {
+		it.index[d]++
+		for k := range it.pos {
+			it.pos[k] += it.strides[k][d]
+		}
+
+		if it.index[d] < it.shape[d] {
+			return
+		}
+
+		for k := range it.pos {
+			it.pos[k] -= it.strides[k][d] * it.shape[d]
+		}
+		it.index[d] = 0
+	}
+}
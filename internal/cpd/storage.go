@@ -5,23 +5,52 @@
 package cpd
 
 import (
+	"errors"
 	"unsafe"
 
 	"github.com/lordlarker/nune"
 	"github.com/lordlarker/nune/internal/slice"
 )
 
-type Storage[T nune.Numeric] struct {
-	data []T
+// ErrReadOnly occurs when writing to a read-only Storage.
+var ErrReadOnly = errors.New("nune/cpd: write to a read-only storage")
+
+type Storage[T nune.Number] struct {
+	data     []T
+	readOnly bool // whether or not data is externally owned and must not be written to
 }
 
-func NewStorage[T nune.Numeric](data []T) *Storage[T] {
+func NewStorage[T nune.Number](data []T) *Storage[T] {
 	s := new(Storage[T])
 	s.data = data
 
 	return s
 }
 
+// NewReadOnlyStorage returns a Storage wrapping externally owned memory,
+// such as a memory-mapped file, which must never be written to.
+// Its Dump, SetIndex and SetSlice methods panic, and its Copy
+// is a regular, writable Storage.
+func NewReadOnlyStorage[T nune.Number](data []T) *Storage[T] {
+	s := NewStorage(data)
+	s.readOnly = true
+
+	return s
+}
+
+// ReadOnly returns whether or not the Storage wraps
+// externally owned memory that must not be written to.
+func (s *Storage[T]) ReadOnly() bool {
+	return s.readOnly
+}
+
+// assertWritable panics with ErrReadOnly if the Storage is read-only.
+func (s *Storage[T]) assertWritable() {
+	if s.readOnly {
+		panic(ErrReadOnly)
+	}
+}
+
 func (s *Storage[T]) Numel() int {
 	return len(s.data)
 }
@@ -35,6 +64,7 @@ func (s *Storage[T]) Load() []T {
 }
 
 func (s *Storage[T]) Dump(data []T) {
+	s.assertWritable()
 	s.data = data
 }
 
@@ -43,6 +73,7 @@ func (s *Storage[T]) Index(idx int) T {
 }
 
 func (s *Storage[T]) SetIndex(idx int, x T) {
+	s.assertWritable()
 	s.data[idx] = x
 }
 
@@ -51,6 +82,7 @@ func (s *Storage[T]) Slice(start, end int) []T {
 }
 
 func (s *Storage[T]) SetSlice(start, end int, x []T) {
+	s.assertWritable()
 	copy(s.data[start:end], x)
 }
 
@@ -0,0 +1,302 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// epsilon is the machine epsilon of float64.
+const epsilon = 0x1p-52
+
+// LU computes the LU decomposition with partial pivoting of the
+// square matrices of the Tensor, such that a = p·l·u, where p is a
+// permutation matrix, l a unit lower triangular matrix and u an upper
+// triangular matrix. Singular matrices are decomposed as well, their
+// singularity showing as null diagonal elements of u.
+func LU[T nune.Float](a *tensor.Tensor[T]) (p, l, u *tensor.Tensor[T], err error) {
+	s, err := unstackSquare(a)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	n := s.n
+	ps, ls, us := newStack(s.batch, n, n), newStack(s.batch, n, n), newStack(s.batch, n, n)
+
+	for b, mat := range s.mats {
+		perm, _ := lu(mat, n)
+
+		for i := 0; i < n; i++ {
+			ps.mats[b][perm[i]*n+i] = 1
+
+			for j := 0; j < n; j++ {
+				switch {
+				case j < i:
+					ls.mats[b][i*n+j] = mat[i*n+j]
+				case j == i:
+					ls.mats[b][i*n+j] = 1
+					us.mats[b][i*n+j] = mat[i*n+j]
+				default:
+					us.mats[b][i*n+j] = mat[i*n+j]
+				}
+			}
+		}
+	}
+
+	return restack[T](ps), restack[T](ls), restack[T](us), nil
+}
+
+// QR computes the reduced QR decomposition of the matrices of the
+// Tensor through Householder reflections, such that a = q·r, where q is
+// an m×k matrix with orthonormal columns and r a k×n upper triangular
+// matrix, k being the least of m and n.
+func QR[T nune.Float](a *tensor.Tensor[T]) (q, r *tensor.Tensor[T], err error) {
+	s, err := unstack(a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, n := s.m, s.n
+	k := min(m, n)
+	qs, rs := newStack(s.batch, m, k), newStack(s.batch, k, n)
+
+	for b, mat := range s.mats {
+		qs.mats[b], rs.mats[b] = qr(mat, m, n)
+	}
+
+	return restack[T](qs), restack[T](rs), nil
+}
+
+// Cholesky computes the Cholesky decomposition of the symmetric
+// positive-definite matrices of the Tensor, such that a = l·lᵀ,
+// where l is a lower triangular matrix. Only the lower triangles
+// of the matrices are read.
+func Cholesky[T nune.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	s, err := unstackSquare(a)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mat := range s.mats {
+		if !cholesky(mat, s.n) {
+			return nil, ErrNotPositiveDefinite
+		}
+	}
+
+	return restack[T](s), nil
+}
+
+// lu decomposes the n×n matrix in place into the strictly lower part of
+// its unit lower triangular factor and its upper triangular factor,
+// and returns the permutation such that the i-th row of the factors'
+// product is the perm[i]-th row of the original matrix, along with
+// the permutation's parity.
+func lu(a []float64, n int) (perm []int, sign float64) {
+	perm = make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	sign = 1
+
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i*n+k]) > math.Abs(a[p*n+k]) {
+				p = i
+			}
+		}
+
+		if p != k {
+			for j := 0; j < n; j++ {
+				a[k*n+j], a[p*n+j] = a[p*n+j], a[k*n+j]
+			}
+			perm[k], perm[p] = perm[p], perm[k]
+			sign = -sign
+		}
+
+		pivot := a[k*n+k]
+		if pivot == 0 {
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			f := a[i*n+k] / pivot
+			a[i*n+k] = f
+
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= f * a[k*n+j]
+			}
+		}
+	}
+
+	return perm, sign
+}
+
+// luSolve solves the system a·x = b in place in b, an n×k matrix, given
+// the LU decomposition of a, and reports whether or not a is invertible.
+func luSolve(lu []float64, perm []int, b []float64, n, k int) bool {
+	for i := 0; i < n; i++ {
+		if lu[i*n+i] == 0 {
+			return false
+		}
+	}
+
+	x := make([]float64, n*k)
+	for i := 0; i < n; i++ {
+		copy(x[i*k:(i+1)*k], b[perm[i]*k:(perm[i]+1)*k])
+	}
+
+	// forward substitution with the unit lower triangular factor
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			f := lu[i*n+j]
+			for c := 0; c < k; c++ {
+				x[i*k+c] -= f * x[j*k+c]
+			}
+		}
+	}
+
+	// backward substitution with the upper triangular factor
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			f := lu[i*n+j]
+			for c := 0; c < k; c++ {
+				x[i*k+c] -= f * x[j*k+c]
+			}
+		}
+
+		for c := 0; c < k; c++ {
+			x[i*k+c] /= lu[i*n+i]
+		}
+	}
+
+	copy(b, x)
+
+	return true
+}
+
+// qr returns the reduced QR decomposition of the m×n matrix,
+// computed through Householder reflections.
+func qr(a []float64, m, n int) (q, r []float64) {
+	k := min(m, n)
+
+	w := make([]float64, m*n)
+	copy(w, a)
+
+	vs := make([][]float64, k)
+	for j := 0; j < k; j++ {
+		v := make([]float64, m-j)
+		for i := range v {
+			v[i] = w[(j+i)*n+j]
+		}
+
+		alpha := norm(v)
+		if v[0] > 0 {
+			alpha = -alpha
+		}
+		v[0] -= alpha
+
+		if vn := norm(v); vn != 0 {
+			for i := range v {
+				v[i] /= vn
+			}
+		}
+		vs[j] = v
+
+		reflect(w, n, j, j, n, v)
+	}
+
+	r = make([]float64, k*n)
+	for i := 0; i < k; i++ {
+		for j := i; j < n; j++ {
+			r[i*n+j] = w[i*n+j]
+		}
+	}
+
+	q = make([]float64, m*k)
+	for i := 0; i < k; i++ {
+		q[i*k+i] = 1
+	}
+	for j := k - 1; j >= 0; j-- {
+		reflect(q, k, j, 0, k, vs[j])
+	}
+
+	return q, r
+}
+
+// reflect applies the Householder reflection I - 2·v·vᵀ to the rows
+// starting at row of the columns [c0, c1) of the matrix with n columns.
+func reflect(a []float64, n, row, c0, c1 int, v []float64) {
+	for c := c0; c < c1; c++ {
+		var d float64
+		for i, x := range v {
+			d += x * a[(row+i)*n+c]
+		}
+
+		d *= 2
+		for i, x := range v {
+			a[(row+i)*n+c] -= d * x
+		}
+	}
+}
+
+// cholesky decomposes the n×n matrix in place into its lower triangular
+// Cholesky factor, and reports whether or not it is positive-definite.
+func cholesky(a []float64, n int) bool {
+	for j := 0; j < n; j++ {
+		s := a[j*n+j]
+		for k := 0; k < j; k++ {
+			s -= a[j*n+k] * a[j*n+k]
+		}
+
+		if s <= 0 || math.IsNaN(s) {
+			return false
+		}
+		a[j*n+j] = math.Sqrt(s)
+
+		for i := j + 1; i < n; i++ {
+			s := a[i*n+j]
+			for k := 0; k < j; k++ {
+				s -= a[i*n+k] * a[j*n+k]
+			}
+			a[i*n+j] = s / a[j*n+j]
+		}
+
+		for i := 0; i < j; i++ {
+			a[i*n+j] = 0
+		}
+	}
+
+	return true
+}
+
+// norm returns the Euclidean norm of the vector.
+func norm(v []float64) float64 {
+	var s float64
+	for _, x := range v {
+		s += x * x
+	}
+
+	return math.Sqrt(s)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,103 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+func TestLU(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *tensor.Tensor[float64]
+	}{
+		{"batch", square()},
+		{"singular", tensor.From[float64]([][]float64{{1, 2}, {2, 4}})},
+		{"1×1", tensor.From[float64]([][]float64{{-3}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, l, u, err := LU(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := tensor.MatMul(p, tensor.MatMul(l, u)); !near(got, tt.a, tol) {
+				t.Errorf("p·l·u: got %v, want %v", got, tt.a)
+			}
+
+			n := tt.a.Size(tt.a.Rank() - 1)
+			lv, uv := l.Ravel(), u.Ravel()
+			for i := range lv {
+				r, c := i/n%n, i%n
+				if c > r && lv[i] != 0 || c == r && lv[i] != 1 || c < r && uv[i] != 0 {
+					t.Fatalf("l: %v, u: %v are not triangular", l, u)
+				}
+			}
+		})
+	}
+
+	if _, _, _, err := LU(wide()); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("got %v, want %v", err, ErrNotSquare)
+	}
+}
+
+func TestQR(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *tensor.Tensor[float64]
+	}{
+		{"square", square()},
+		{"tall", tall()},
+		{"wide", wide()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, r, err := QR(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := tensor.MatMul(q, r); !near(got, tt.a, tol) {
+				t.Errorf("q·r: got %v, want %v", got, tt.a)
+			}
+
+			k := q.Size(q.Rank() - 1)
+			if got := tensor.MatMul(mT(q), q); !near(got, eye(k, q.Shape()[:q.Rank()-2]...), tol) {
+				t.Errorf("qᵀ·q: got %v, want the identity", got)
+			}
+
+			n := r.Size(r.Rank() - 1)
+			rv := r.Ravel()
+			for i := range rv {
+				if i/n%k > i%n && rv[i] != 0 {
+					t.Fatalf("r: %v is not upper triangular", r)
+				}
+			}
+		})
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	a := square().Index(0)
+
+	l, err := Cholesky(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tensor.MatMul(l, mT(l)); !near(got, a, tol) {
+		t.Errorf("l·lᵀ: got %v, want %v", got, a)
+	}
+
+	if _, err := Cholesky(square()); !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("got %v, want %v", err, ErrNotPositiveDefinite)
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package linalg implements dense linear algebra routines
+// over floating-point Tensors.
+//
+// Tensors of rank 2 or more are treated as stacks of matrices held
+// in their last two axes, and every routine operates on each matrix
+// of the stack independently. Unlike the tensor package, invalid
+// inputs are reported through returned errors rather than panics.
+package linalg
@@ -0,0 +1,723 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// maxSweeps is the maximum number of sweeps performed
+// by the Jacobi eigenvalue and singular value algorithms.
+const maxSweeps = 100
+
+// maxQRIters is the maximum number of iterations per eigenvalue
+// performed by the shifted QR algorithm, as in LAPACK.
+const maxQRIters = 30
+
+// Eigh computes the eigenvalues and eigenvectors of the symmetric
+// matrices of the Tensor through the cyclic Jacobi algorithm.
+// The eigenvalues are returned in ascending order, and the i-th column
+// of the returned matrices is the unit eigenvector of the i-th one.
+// Only the lower triangles of the matrices are read.
+func Eigh[T nune.Float](a *tensor.Tensor[T]) (w, v *tensor.Tensor[T], err error) {
+	s, err := unstackSquare(a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n := s.n
+	ws := make([][]float64, len(s.mats))
+	vs := newStack(s.batch, n, n)
+
+	for b, mat := range s.mats {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				mat[i*n+j] = mat[j*n+i]
+			}
+		}
+
+		vals, vecs := jacobiEigen(mat, n)
+		order := argsort(vals)
+
+		ws[b] = make([]float64, n)
+		for j, o := range order {
+			ws[b][j] = vals[o]
+			for i := 0; i < n; i++ {
+				vs.mats[b][i*n+j] = vecs[i*n+o]
+			}
+		}
+	}
+
+	return vectors[T](s.batch, ws), restack[T](vs), nil
+}
+
+// Eig computes the eigenvalues and right eigenvectors of the square
+// matrices of the Tensor, which can be complex, in ComplexTensors: the
+// eigenvalues in one whose shape is the Tensor's batch shape followed by
+// n, and the unit eigenvectors as the columns of n×n matrices. Complex
+// conjugate eigenvalues are returned consecutively, the one with a
+// positive imaginary part first.
+//
+// The matrices are reduced to Hessenberg form, and then to real Schur
+// form through the shifted QR algorithm. Matrices holding NaNs or
+// infinities are rejected with ErrNotFinite, and those for which the
+// algorithm doesn't converge with ErrNoConvergence.
+func Eig[T nune.Float](a *tensor.Tensor[T]) (w, v *tensor.ComplexTensor[complex128], err error) {
+	s, err := unstackSquare(a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, mat := range s.mats {
+		for _, x := range mat {
+			if math.IsNaN(x) || math.IsInf(x, 0) {
+				return nil, nil, ErrNotFinite
+			}
+		}
+	}
+
+	n := s.n
+	ws := make([]complex128, 0, len(s.mats)*n)
+	vs := make([]complex128, len(s.mats)*n*n)
+
+	for b, mat := range s.mats {
+		d, e, vecs, ok := hessenbergEigen(mat, n)
+		if !ok {
+			return nil, nil, ErrNoConvergence
+		}
+
+		for j := 0; j < n; j++ {
+			ws = append(ws, complex(d[j], e[j]))
+		}
+
+		dst := vs[b*n*n : (b+1)*n*n]
+		for j := 0; j < n; j++ {
+			// the columns j and j+1 of a conjugate pair hold the
+			// real and imaginary parts of the first one's eigenvector
+			paired := e[j] != 0 && j+1 < n && e[j+1] == -e[j] && d[j+1] == d[j]
+
+			for i := 0; i < n; i++ {
+				if paired {
+					dst[i*n+j] = complex(vecs[i*n+j], vecs[i*n+j+1])
+					dst[i*n+j+1] = complex(vecs[i*n+j], -vecs[i*n+j+1])
+				} else {
+					dst[i*n+j] = complex(vecs[i*n+j], 0)
+				}
+			}
+
+			normalize(dst, n, j)
+			if paired {
+				normalize(dst, n, j+1)
+				j++
+			}
+		}
+	}
+
+	batch := s.batch
+	w = tensor.ComplexFromBuffer(ws, append(append([]int{}, batch...), n)...)
+	v = tensor.ComplexFromBuffer(vs, append(append([]int{}, batch...), n, n)...)
+
+	return w, v, nil
+}
+
+// normalize scales the j-th column of the
+// complex n×n matrix to unit length.
+func normalize(a []complex128, n, j int) {
+	var norm float64
+	for i := 0; i < n; i++ {
+		x := a[i*n+j]
+		norm += real(x)*real(x) + imag(x)*imag(x)
+	}
+
+	if norm == 0 {
+		return
+	}
+
+	norm = math.Sqrt(norm)
+	for i := 0; i < n; i++ {
+		a[i*n+j] /= complex(norm, 0)
+	}
+}
+
+// jacobiEigen returns the eigenvalues and the eigenvectors, as the
+// columns of an n×n matrix, of the symmetric n×n matrix, which it
+// diagonalizes in place through Jacobi rotations.
+func jacobiEigen(a []float64, n int) ([]float64, []float64) {
+	v := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+	}
+
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		var off, diag float64
+		for i := 0; i < n; i++ {
+			diag += a[i*n+i] * a[i*n+i]
+			for j := i + 1; j < n; j++ {
+				off += a[i*n+j] * a[i*n+j]
+			}
+		}
+
+		if off <= epsilon*epsilon*diag || off == 0 {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p*n+q] == 0 {
+					continue
+				}
+
+				theta := (a[q*n+q] - a[p*n+p]) / (2 * a[p*n+q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				rotateCols(a, n, n, p, q, c, s)
+				rotateRows(a, n, p, q, c, s)
+				rotateCols(v, n, n, p, q, c, s)
+			}
+		}
+	}
+
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = a[i*n+i]
+	}
+
+	return w, v
+}
+
+// rotateCols applies the plane rotation of cosine c and sine s to the
+// columns p and q of the m×n matrix.
+func rotateCols(a []float64, m, n, p, q int, c, s float64) {
+	for k := 0; k < m; k++ {
+		x, y := a[k*n+p], a[k*n+q]
+		a[k*n+p] = c*x - s*y
+		a[k*n+q] = s*x + c*y
+	}
+}
+
+// rotateRows applies the plane rotation of cosine c and sine s to the
+// rows p and q of the matrix with n columns.
+func rotateRows(a []float64, n, p, q int, c, s float64) {
+	for k := 0; k < n; k++ {
+		x, y := a[p*n+k], a[q*n+k]
+		a[p*n+k] = c*x - s*y
+		a[q*n+k] = s*x + c*y
+	}
+}
+
+// argsort returns the indices that sort the values in ascending order.
+func argsort(xs []float64) []int {
+	idx := make([]int, len(xs))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return xs[idx[i]] < xs[idx[j]]
+	})
+
+	return idx
+}
+
+// hessenbergEigen returns the real parts d and imaginary parts e of the
+// eigenvalues of the general n×n matrix, along with the n×n matrix v
+// holding its eigenvectors in the compact real form: the j-th column is
+// the eigenvector of a real eigenvalue, while the j-th and (j+1)-th
+// columns are the real and imaginary parts of the eigenvector of a
+// complex eigenvalue whose imaginary part e[j] is positive.
+//
+// The matrix is overwritten by its reduction to Hessenberg form, and
+// then to real Schur form. This follows the EISPACK routines orthes
+// and hqr2, as adapted by the JAMA library. It returns false if the
+// QR algorithm doesn't converge.
+func hessenbergEigen(h []float64, n int) (d, e, v []float64, ok bool) {
+	v = make([]float64, n*n)
+	orthes(h, v, n)
+
+	d, e = make([]float64, n), make([]float64, n)
+	ok = hqr2(h, v, d, e, n)
+
+	return d, e, v, ok
+}
+
+// orthes reduces the n×n matrix h to upper Hessenberg form through
+// orthogonal similarity transformations, which it accumulates in v.
+func orthes(h, v []float64, n int) {
+	low, high := 0, n-1
+	ort := make([]float64, n)
+
+	for m := low + 1; m <= high-1; m++ {
+		var scale float64
+		for i := m; i <= high; i++ {
+			scale += math.Abs(h[i*n+m-1])
+		}
+
+		if scale == 0 {
+			continue
+		}
+
+		// compute the Householder transformation
+		var hh float64
+		for i := high; i >= m; i-- {
+			ort[i] = h[i*n+m-1] / scale
+			hh += ort[i] * ort[i]
+		}
+
+		g := math.Sqrt(hh)
+		if ort[m] > 0 {
+			g = -g
+		}
+		hh -= ort[m] * g
+		ort[m] -= g
+
+		// apply the Householder similarity transformation
+		for j := m; j < n; j++ {
+			var f float64
+			for i := high; i >= m; i-- {
+				f += ort[i] * h[i*n+j]
+			}
+			f /= hh
+
+			for i := m; i <= high; i++ {
+				h[i*n+j] -= f * ort[i]
+			}
+		}
+
+		for i := 0; i <= high; i++ {
+			var f float64
+			for j := high; j >= m; j-- {
+				f += ort[j] * h[i*n+j]
+			}
+			f /= hh
+
+			for j := m; j <= high; j++ {
+				h[i*n+j] -= f * ort[j]
+			}
+		}
+
+		ort[m] *= scale
+		h[m*n+m-1] = scale * g
+	}
+
+	// accumulate the transformations
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			v[i*n+j] = 0
+		}
+		v[i*n+i] = 1
+	}
+
+	for m := high - 1; m >= low+1; m-- {
+		if h[m*n+m-1] == 0 {
+			continue
+		}
+
+		for i := m + 1; i <= high; i++ {
+			ort[i] = h[i*n+m-1]
+		}
+
+		for j := m; j <= high; j++ {
+			var g float64
+			for i := m; i <= high; i++ {
+				g += ort[i] * v[i*n+j]
+			}
+
+			// double division avoids possible underflow
+			g = (g / ort[m]) / h[m*n+m-1]
+			for i := m; i <= high; i++ {
+				v[i*n+j] += g * ort[i]
+			}
+		}
+	}
+}
+
+// hqr2 reduces the n×n upper Hessenberg matrix h to real Schur form
+// through the shifted QR algorithm, writing the real and imaginary parts
+// of its eigenvalues into d and e, and transforms the accumulated
+// transformations v into the eigenvectors of the original matrix.
+// It returns false if the algorithm doesn't converge within
+// maxQRIters iterations per eigenvalue.
+func hqr2(h, v, d, e []float64, nn int) bool {
+	at := func(i, j int) *float64 {
+		return &h[i*nn+j]
+	}
+
+	n := nn - 1
+	low, high := 0, nn-1
+	var exshift, p, q, r, s, z, t, w, x, y float64
+
+	// compute the matrix norm
+	var norm float64
+	for i := 0; i < nn; i++ {
+		for j := max(i-1, 0); j < nn; j++ {
+			norm += math.Abs(*at(i, j))
+		}
+	}
+
+	iter, total := 0, 0
+	for n >= low {
+		// look for a single small sub-diagonal element
+		l := n
+		for l > low {
+			s = math.Abs(*at(l-1, l-1)) + math.Abs(*at(l, l))
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(*at(l, l-1)) < epsilon*s {
+				break
+			}
+			l--
+		}
+
+		switch {
+		case l == n: // one root found
+			*at(n, n) += exshift
+			d[n], e[n] = *at(n, n), 0
+			n--
+			iter = 0
+		case l == n-1: // two roots found
+			w = *at(n, n-1) * *at(n-1, n)
+			p = (*at(n-1, n-1) - *at(n, n)) / 2
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			*at(n, n) += exshift
+			*at(n-1, n-1) += exshift
+			x = *at(n, n)
+
+			if q >= 0 { // real pair
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+
+				d[n-1] = x + z
+				d[n] = d[n-1]
+				if z != 0 {
+					d[n] = x - w/z
+				}
+				e[n-1], e[n] = 0, 0
+
+				x = *at(n, n-1)
+				s = math.Abs(x) + math.Abs(z)
+				p, q = x/s, z/s
+				r = math.Sqrt(p*p + q*q)
+				p, q = p/r, q/r
+
+				// row modification
+				for j := n - 1; j < nn; j++ {
+					z = *at(n-1, j)
+					*at(n-1, j) = q*z + p**at(n, j)
+					*at(n, j) = q**at(n, j) - p*z
+				}
+
+				// column modification
+				for i := 0; i <= n; i++ {
+					z = *at(i, n-1)
+					*at(i, n-1) = q*z + p**at(i, n)
+					*at(i, n) = q**at(i, n) - p*z
+				}
+
+				// accumulate transformations
+				for i := low; i <= high; i++ {
+					z = v[i*nn+n-1]
+					v[i*nn+n-1] = q*z + p*v[i*nn+n]
+					v[i*nn+n] = q*v[i*nn+n] - p*z
+				}
+			} else { // complex pair
+				d[n-1], d[n] = x+p, x+p
+				e[n-1], e[n] = z, -z
+			}
+
+			n -= 2
+			iter = 0
+		default: // no convergence yet
+			// form the shift
+			x = *at(n, n)
+			y, w = 0, 0
+			if l < n {
+				y = *at(n-1, n-1)
+				w = *at(n, n-1) * *at(n-1, n)
+			}
+
+			// Wilkinson's original ad hoc shift
+			if iter == 10 {
+				exshift += x
+				for i := low; i <= n; i++ {
+					*at(i, i) -= x
+				}
+
+				s = math.Abs(*at(n, n-1)) + math.Abs(*at(n-1, n-2))
+				x, y = 0.75*s, 0.75*s
+				w = -0.4375 * s * s
+			}
+
+			// MATLAB's ad hoc shift
+			if iter == 30 {
+				s = (y - x) / 2
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2+s)
+					for i := low; i <= n; i++ {
+						*at(i, i) -= s
+					}
+					exshift += s
+					x, y, w = 0.964, 0.964, 0.964
+				}
+			}
+
+			iter++
+			total++
+			if total > maxQRIters*nn {
+				return false
+			}
+
+			// look for two consecutive small sub-diagonal elements
+			m := n - 2
+			for m >= l {
+				z = *at(m, m)
+				r = x - z
+				s = y - z
+				p = (r*s-w) / *at(m+1, m) + *at(m, m+1)
+				q = *at(m+1, m+1) - z - r - s
+				r = *at(m+2, m+1)
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p, q, r = p/s, q/s, r/s
+
+				if m == l {
+					break
+				}
+
+				if math.Abs(*at(m, m-1))*(math.Abs(q)+math.Abs(r)) <
+					epsilon*(math.Abs(p)*(math.Abs(*at(m-1, m-1))+math.Abs(z)+math.Abs(*at(m+1, m+1)))) {
+					break
+				}
+				m--
+			}
+
+			for i := m + 2; i <= n; i++ {
+				*at(i, i-2) = 0
+				if i > m+2 {
+					*at(i, i-3) = 0
+				}
+			}
+
+			// double QR step involving rows l:n and columns m:n
+			for k := m; k <= n-1; k++ {
+				notlast := k != n-1
+
+				if k != m {
+					p = *at(k, k-1)
+					q = *at(k+1, k-1)
+					r = 0
+					if notlast {
+						r = *at(k+2, k-1)
+					}
+
+					x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+					if x == 0 {
+						continue
+					}
+					p, q, r = p/x, q/x, r/x
+				}
+
+				s = math.Sqrt(p*p + q*q + r*r)
+				if p < 0 {
+					s = -s
+				}
+
+				if s == 0 {
+					continue
+				}
+
+				if k != m {
+					*at(k, k-1) = -s * x
+				} else if l != m {
+					*at(k, k-1) = -*at(k, k-1)
+				}
+
+				p += s
+				x, y, z = p/s, q/s, r/s
+				q, r = q/p, r/p
+
+				// row modification
+				for j := k; j < nn; j++ {
+					p = *at(k, j) + q**at(k+1, j)
+					if notlast {
+						p += r * *at(k+2, j)
+						*at(k+2, j) -= p * z
+					}
+					*at(k, j) -= p * x
+					*at(k+1, j) -= p * y
+				}
+
+				// column modification
+				for i := 0; i <= min(n, k+3); i++ {
+					p = x**at(i, k) + y**at(i, k+1)
+					if notlast {
+						p += z * *at(i, k+2)
+						*at(i, k+2) -= p * r
+					}
+					*at(i, k) -= p
+					*at(i, k+1) -= p * q
+				}
+
+				// accumulate transformations
+				for i := low; i <= high; i++ {
+					p = x*v[i*nn+k] + y*v[i*nn+k+1]
+					if notlast {
+						p += z * v[i*nn+k+2]
+						v[i*nn+k+2] -= p * r
+					}
+					v[i*nn+k] -= p
+					v[i*nn+k+1] -= p * q
+				}
+			}
+		}
+	}
+
+	if norm == 0 {
+		return true
+	}
+
+	// back-substitute to find the vectors of the upper triangular form
+	for n = nn - 1; n >= 0; n-- {
+		p, q = d[n], e[n]
+
+		if q == 0 { // real vector
+			l := n
+			*at(n, n) = 1
+
+			for i := n - 1; i >= 0; i-- {
+				w = *at(i, i) - p
+				r = 0
+				for j := l; j <= n; j++ {
+					r += *at(i, j) * *at(j, n)
+				}
+
+				if e[i] < 0 {
+					z, s = w, r
+					continue
+				}
+
+				l = i
+				if e[i] == 0 {
+					if w != 0 {
+						*at(i, n) = -r / w
+					} else {
+						*at(i, n) = -r / (epsilon * norm)
+					}
+				} else { // solve real equations
+					x = *at(i, i+1)
+					y = *at(i+1, i)
+					q = (d[i]-p)*(d[i]-p) + e[i]*e[i]
+					t = (x*s - z*r) / q
+					*at(i, n) = t
+
+					if math.Abs(x) > math.Abs(z) {
+						*at(i+1, n) = (-r - w*t) / x
+					} else {
+						*at(i+1, n) = (-s - y*t) / z
+					}
+				}
+
+				// overflow control
+				t = math.Abs(*at(i, n))
+				if (epsilon*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						*at(j, n) /= t
+					}
+				}
+			}
+		} else if q < 0 { // complex vector
+			l := n - 1
+
+			// the last vector component is imaginary, so the matrix is triangular
+			if math.Abs(*at(n, n-1)) > math.Abs(*at(n-1, n)) {
+				*at(n-1, n-1) = q / *at(n, n-1)
+				*at(n-1, n) = -(*at(n, n) - p) / *at(n, n-1)
+			} else {
+				c := complex(0, -*at(n-1, n)) / complex(*at(n-1, n-1)-p, q)
+				*at(n-1, n-1), *at(n-1, n) = real(c), imag(c)
+			}
+			*at(n, n-1) = 0
+			*at(n, n) = 1
+
+			for i := n - 2; i >= 0; i-- {
+				var ra, sa float64
+				for j := l; j <= n; j++ {
+					ra += *at(i, j) * *at(j, n-1)
+					sa += *at(i, j) * *at(j, n)
+				}
+				w = *at(i, i) - p
+
+				if e[i] < 0 {
+					z, r, s = w, ra, sa
+					continue
+				}
+
+				l = i
+				if e[i] == 0 {
+					c := complex(-ra, -sa) / complex(w, q)
+					*at(i, n-1), *at(i, n) = real(c), imag(c)
+				} else { // solve complex equations
+					x = *at(i, i+1)
+					y = *at(i+1, i)
+					vr := (d[i]-p)*(d[i]-p) + e[i]*e[i] - q*q
+					vi := (d[i] - p) * 2 * q
+					if vr == 0 && vi == 0 {
+						vr = epsilon * norm * (math.Abs(w) + math.Abs(q) + math.Abs(x) + math.Abs(y) + math.Abs(z))
+					}
+
+					c := complex(x*r-z*ra+q*sa, x*s-z*sa-q*ra) / complex(vr, vi)
+					*at(i, n-1), *at(i, n) = real(c), imag(c)
+
+					if math.Abs(x) > math.Abs(z)+math.Abs(q) {
+						*at(i+1, n-1) = (-ra - w**at(i, n-1) + q**at(i, n)) / x
+						*at(i+1, n) = (-sa - w**at(i, n) - q**at(i, n-1)) / x
+					} else {
+						c := complex(-r-y**at(i, n-1), -s-y**at(i, n)) / complex(z, q)
+						*at(i+1, n-1), *at(i+1, n) = real(c), imag(c)
+					}
+				}
+
+				// overflow control
+				t = math.Max(math.Abs(*at(i, n-1)), math.Abs(*at(i, n)))
+				if (epsilon*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						*at(j, n-1) /= t
+						*at(j, n) /= t
+					}
+				}
+			}
+		}
+	}
+
+	// back-transform to get the eigenvectors of the original matrix
+	for j := nn - 1; j >= low; j-- {
+		for i := low; i <= high; i++ {
+			z = 0
+			for k := low; k <= min(j, high); k++ {
+				z += v[i*nn+k] * *at(k, j)
+			}
+			v[i*nn+j] = z
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,97 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+func TestEigh(t *testing.T) {
+	sym := tensor.From[float64]([][][]float64{
+		{{4, 1, 2}, {1, 5, 3}, {2, 3, 6}},
+		{{2, 0, 0}, {0, -1, 0}, {0, 0, 2}},
+	})
+
+	w, v, err := Eigh(sym)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a·v = v·diag(w)
+	if got, want := tensor.MatMul(sym, v), tensor.Mul(v, w.Unsqueeze(1)); !near(got, want, tol) {
+		t.Errorf("a·v: got %v, want %v", got, want)
+	}
+
+	if got := tensor.MatMul(mT(v), v); !near(got, eye(3, 2), tol) {
+		t.Errorf("vᵀ·v: got %v, want the identity", got)
+	}
+
+	ws := w.Ravel()
+	for i := 1; i < len(ws); i++ {
+		if i%3 != 0 && ws[i] < ws[i-1] {
+			t.Fatalf("w: %v is not in ascending order", w)
+		}
+	}
+}
+
+func TestEig(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *tensor.Tensor[float64]
+	}{
+		{"real", tensor.From[float64]([][]float64{{2, 1}, {1, 3}})},
+		{"rotation", tensor.From[float64]([][]float64{{0, -1}, {1, 0}})},
+		{"nonsymmetric", tensor.From[float64]([][]float64{{1, 2, 3}, {0, 4, 5}, {1, 0, 6}})},
+		{"complex pair", tensor.From[float64]([][]float64{{1, -2, 0, 0}, {3, 1, 0, 1}, {0, 0, 2, 0}, {1, 0, 0, -1}})},
+		{"batch", square()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, v, err := Eig(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := tt.a.Rank()
+			n := tt.a.Size(r - 1)
+			a, ws, vs := tt.a.Ravel(), w.Ravel(), v.Ravel()
+
+			// a·v = v·diag(w)
+			for b := 0; b < len(a)/(n*n); b++ {
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						var av complex128
+						for k := 0; k < n; k++ {
+							av += complex(a[b*n*n+i*n+k], 0) * vs[b*n*n+k*n+j]
+						}
+
+						if want := vs[b*n*n+i*n+j] * ws[b*n+j]; cmplx.Abs(av-want) > 1e-8 {
+							t.Fatalf("a·v: got %v, want %v at (%d, %d, %d)", av, want, b, i, j)
+						}
+					}
+
+					if j := i; imag(ws[b*n+j]) < 0 && (j == 0 || ws[b*n+j-1] != cmplx.Conj(ws[b*n+j])) {
+						t.Fatalf("w: %v doesn't hold conjugate pairs in order", w.Ravel())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEigNotFinite(t *testing.T) {
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		a := tensor.From[float64]([][]float64{{1, x}, {3, 4}})
+		if _, _, err := Eig(a); !errors.Is(err, ErrNotFinite) {
+			t.Errorf("%v: got %v, want %v", x, err, ErrNotFinite)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import "errors"
+
+// List of errors.
+var (
+	// ErrNotMatrix occurs when a Tensor of rank
+	// less than 2 is given where matrices are expected.
+	ErrNotMatrix = errors.New("nune/linalg: received a Tensor of rank less than 2")
+
+	// ErrNotSquare occurs when a routine requiring
+	// square matrices receives non-square ones.
+	ErrNotSquare = errors.New("nune/linalg: received non-square matrices")
+
+	// ErrShapeMismatch occurs when the shapes of
+	// the operands of a routine don't agree.
+	ErrShapeMismatch = errors.New("nune/linalg: received operands with mismatched shapes")
+
+	// ErrBadOrd occurs when a norm's order is not supported
+	// for the given Tensor.
+	ErrBadOrd = errors.New("nune/linalg: received an unsupported norm order")
+
+	// ErrSingular occurs when a matrix that must
+	// be invertible is singular.
+	ErrSingular = errors.New("nune/linalg: matrix is singular")
+
+	// ErrNotPositiveDefinite occurs when a matrix that must
+	// be positive-definite is not.
+	ErrNotPositiveDefinite = errors.New("nune/linalg: matrix is not positive-definite")
+
+	// ErrNotFinite occurs when a routine which requires
+	// finite elements receives NaNs or infinities.
+	ErrNotFinite = errors.New("nune/linalg: received non-finite elements")
+
+	// ErrNoConvergence occurs when an iterative algorithm
+	// fails to converge.
+	ErrNoConvergence = errors.New("nune/linalg: algorithm did not converge")
+)
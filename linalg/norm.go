@@ -0,0 +1,156 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// ordKind distinguishes the p-norms from the matrix-only norms.
+type ordKind int
+
+const (
+	pNorm ordKind = iota
+	froNorm
+	nucNorm
+)
+
+// An Ord is the order of a norm computed by Norm.
+type Ord struct {
+	kind ordKind
+	p    float64
+}
+
+// P returns the order of the p-norm, p being any number,
+// including math.Inf(1) and math.Inf(-1).
+func P(p float64) Ord {
+	return Ord{kind: pNorm, p: p}
+}
+
+// List of the orders of the matrix-only norms.
+var (
+	Fro = Ord{kind: froNorm} // the Frobenius norm
+	Nuc = Ord{kind: nucNorm} // the nuclear norm
+)
+
+// Norm returns the norm of the given order of a vector, if the Tensor
+// is of rank 1, or of each of its matrices otherwise, in a Tensor whose
+// shape is the Tensor's batch shape.
+//
+// Vectors support every p-norm, where P(0) counts the non-zero elements,
+// and Fro, which is the 2-norm. Matrices support Fro, Nuc, P(1) and P(-1)
+// for the maximum and minimum absolute column sums, P(2) and P(-2) for
+// the largest and smallest singular values, and P(math.Inf(1)) and
+// P(math.Inf(-1)) for the maximum and minimum absolute row sums.
+func Norm[T nune.Float](a *tensor.Tensor[T], ord Ord) (*tensor.Tensor[T], error) {
+	if a.Rank() == 1 {
+		return vectorNorm(a, ord)
+	}
+
+	st, err := unstack(a)
+	if err != nil {
+		return nil, err
+	}
+
+	m, n := st.m, st.n
+	norms := make([]float64, len(st.mats))
+
+	for b, mat := range st.mats {
+		switch {
+		case ord.kind == froNorm:
+			norms[b] = norm(mat)
+		case ord.kind == nucNorm:
+			_, s, _ := svd(mat, m, n, false)
+			for _, x := range s {
+				norms[b] += x
+			}
+		case math.Abs(ord.p) == 2:
+			_, s, _ := svd(mat, m, n, false)
+			norms[b] = s[0]
+			if ord.p < 0 {
+				norms[b] = s[len(s)-1]
+			}
+		case math.Abs(ord.p) == 1:
+			norms[b] = extremumSum(mat, n, m, 1, n, ord.p > 0)
+		case math.IsInf(ord.p, 0):
+			norms[b] = extremumSum(mat, m, n, n, 1, ord.p > 0)
+		default:
+			return nil, ErrBadOrd
+		}
+	}
+
+	return scalars[T](st.batch, norms), nil
+}
+
+// vectorNorm returns the norm of the given order of the rank 1 Tensor.
+func vectorNorm[T nune.Float](a *tensor.Tensor[T], ord Ord) (*tensor.Tensor[T], error) {
+	var res float64
+
+	switch p := ord.p; {
+	case ord.kind == froNorm:
+		res = vectorNormP(a.Ravel(), 2)
+	case ord.kind == nucNorm:
+		return nil, ErrBadOrd
+	case math.IsInf(p, 1):
+		res = math.Inf(-1)
+		for _, x := range a.Ravel() {
+			res = math.Max(res, math.Abs(float64(x)))
+		}
+	case math.IsInf(p, -1):
+		res = math.Inf(1)
+		for _, x := range a.Ravel() {
+			res = math.Min(res, math.Abs(float64(x)))
+		}
+	case p == 0:
+		for _, x := range a.Ravel() {
+			if x != 0 {
+				res++
+			}
+		}
+	default:
+		res = vectorNormP(a.Ravel(), p)
+	}
+
+	return scalars[T](nil, []float64{res}), nil
+}
+
+// vectorNormP returns the p-norm of the vector.
+func vectorNormP[T nune.Float](v []T, p float64) float64 {
+	var s float64
+	for _, x := range v {
+		s += math.Pow(math.Abs(float64(x)), p)
+	}
+
+	return math.Pow(s, 1/p)
+}
+
+// extremumSum returns the largest, or smallest, sum of the absolute
+// values of the count lines of the matrix, which hold length elements,
+// lines starting every step elements and their elements being
+// stride elements apart.
+func extremumSum(mat []float64, count, length, step, stride int, largest bool) float64 {
+	res := math.Inf(1)
+	if largest {
+		res = math.Inf(-1)
+	}
+
+	for l := 0; l < count; l++ {
+		var s float64
+		for i := 0; i < length; i++ {
+			s += math.Abs(mat[l*step+i*stride])
+		}
+
+		if largest {
+			res = math.Max(res, s)
+		} else {
+			res = math.Min(res, s)
+		}
+	}
+
+	return res
+}
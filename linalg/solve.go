@@ -0,0 +1,271 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// Solve solves the linear systems a·x = b for x, where a holds square
+// matrices and b either matrices or, if it is of rank 1, a single vector.
+// The batch axes of a and b are broadcasted together.
+func Solve[T nune.Float](a, b *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	vec := b.Rank() == 1
+	if vec {
+		b = b.Reshape(b.Size(), 1)
+	}
+
+	sa, sb, err := unstackPair(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if sa.m != sa.n {
+		return nil, ErrNotSquare
+	} else if sb.m != sa.n {
+		return nil, ErrShapeMismatch
+	}
+
+	for i, mat := range sa.mats {
+		perm, _ := lu(mat, sa.n)
+		if !luSolve(mat, perm, sb.mats[i], sb.m, sb.n) {
+			return nil, ErrSingular
+		}
+	}
+
+	x := restack[T](sb)
+	if vec {
+		x = x.Reshape(x.Shape()[:x.Rank()-1]...)
+	}
+
+	return x, nil
+}
+
+// Inv returns the inverses of the square matrices of the Tensor.
+func Inv[T nune.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	s, err := unstackSquare(a)
+	if err != nil {
+		return nil, err
+	}
+
+	n := s.n
+	inv := newStack(s.batch, n, n)
+
+	for i, mat := range s.mats {
+		for j := 0; j < n; j++ {
+			inv.mats[i][j*n+j] = 1
+		}
+
+		perm, _ := lu(mat, n)
+		if !luSolve(mat, perm, inv.mats[i], n, n) {
+			return nil, ErrSingular
+		}
+	}
+
+	return restack[T](inv), nil
+}
+
+// Det returns the determinants of the square matrices of the Tensor,
+// in a Tensor whose shape is the Tensor's batch shape.
+func Det[T nune.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	s, err := unstackSquare(a)
+	if err != nil {
+		return nil, err
+	}
+
+	dets := make([]float64, len(s.mats))
+	for i, mat := range s.mats {
+		_, sign := lu(mat, s.n)
+
+		dets[i] = sign
+		for j := 0; j < s.n; j++ {
+			dets[i] *= mat[j*s.n+j]
+		}
+	}
+
+	return scalars[T](s.batch, dets), nil
+}
+
+// SlogDet returns the signs and the natural logarithms of the absolute
+// values of the determinants of the square matrices of the Tensor,
+// which avoids the overflows and underflows Det is subject to.
+// Singular matrices have a sign of 0 and a logarithm of -Inf.
+func SlogDet[T nune.Float](a *tensor.Tensor[T]) (sign, logdet *tensor.Tensor[T], err error) {
+	s, err := unstackSquare(a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signs := make([]float64, len(s.mats))
+	logs := make([]float64, len(s.mats))
+
+	for i, mat := range s.mats {
+		_, signs[i] = lu(mat, s.n)
+
+		for j := 0; j < s.n; j++ {
+			d := mat[j*s.n+j]
+			if d == 0 {
+				signs[i], logs[i] = 0, math.Inf(-1)
+				break
+			} else if d < 0 {
+				signs[i] = -signs[i]
+			}
+
+			logs[i] += math.Log(math.Abs(d))
+		}
+	}
+
+	return scalars[T](s.batch, signs), scalars[T](s.batch, logs), nil
+}
+
+// Lstsq returns the least-squares solutions to the linear systems
+// a·x = b, where a holds m×n matrices of full rank and b either matrices
+// or, if it is of rank 1, a single vector. For overdetermined systems,
+// the solutions minimize the Euclidean norm of a·x - b, and for
+// underdetermined ones, they are the solutions of minimum norm.
+// The batch axes of a and b are broadcasted together.
+func Lstsq[T nune.Float](a, b *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	vec := b.Rank() == 1
+	if vec {
+		b = b.Reshape(b.Size(), 1)
+	}
+
+	sa, sb, err := unstackPair(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	m, n, k := sa.m, sa.n, sb.n
+	if sb.m != m {
+		return nil, ErrShapeMismatch
+	}
+
+	xs := newStack(sa.batch, n, k)
+
+	for i, mat := range sa.mats {
+		var ok bool
+		if m >= n {
+			ok = lstsqOver(mat, sb.mats[i], xs.mats[i], m, n, k)
+		} else {
+			ok = lstsqUnder(mat, sb.mats[i], xs.mats[i], m, n, k)
+		}
+
+		if !ok {
+			return nil, ErrSingular
+		}
+	}
+
+	x := restack[T](xs)
+	if vec {
+		x = x.Reshape(x.Shape()[:x.Rank()-1]...)
+	}
+
+	return x, nil
+}
+
+// lstsqOver writes into x the least-squares solution of the
+// overdetermined system a·x = b, where a = q·r, by solving r·x = qᵀ·b.
+func lstsqOver(a, b, x []float64, m, n, k int) bool {
+	q, r := qr(a, m, n)
+	if !fullRank(r, n, m) {
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		for c := 0; c < k; c++ {
+			var s float64
+			for j := 0; j < m; j++ {
+				s += q[j*n+i] * b[j*k+c]
+			}
+			x[i*k+c] = s
+		}
+	}
+
+	return solveUpper(r, x, n, k)
+}
+
+// lstsqUnder writes into x the minimum norm solution of the
+// underdetermined system a·x = b, where aᵀ = q·r, by solving
+// rᵀ·y = b and computing x = q·y.
+func lstsqUnder(a, b, x []float64, m, n, k int) bool {
+	at := make([]float64, n*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			at[j*m+i] = a[i*n+j]
+		}
+	}
+
+	q, r := qr(at, n, m)
+	if !fullRank(r, m, n) {
+		return false
+	}
+
+	y := make([]float64, m*k)
+	copy(y, b)
+
+	// forward substitution with the lower triangular rᵀ
+	for i := 0; i < m; i++ {
+		for c := 0; c < k; c++ {
+			for j := 0; j < i; j++ {
+				y[i*k+c] -= r[j*m+i] * y[j*k+c]
+			}
+			y[i*k+c] /= r[i*m+i]
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for c := 0; c < k; c++ {
+			var s float64
+			for j := 0; j < m; j++ {
+				s += q[i*m+j] * y[j*k+c]
+			}
+			x[i*k+c] = s
+		}
+	}
+
+	return true
+}
+
+// solveUpper solves the system r·x = b in place in b, an n×k matrix,
+// where r is an n×n upper triangular matrix, and reports whether
+// or not r is invertible.
+func solveUpper(r, b []float64, n, k int) bool {
+	for i := n - 1; i >= 0; i-- {
+		if r[i*n+i] == 0 {
+			return false
+		}
+
+		for c := 0; c < k; c++ {
+			for j := i + 1; j < n; j++ {
+				b[i*k+c] -= r[i*n+j] * b[j*k+c]
+			}
+			b[i*k+c] /= r[i*n+i]
+		}
+	}
+
+	return true
+}
+
+// fullRank reports whether or not none of the diagonal elements of the
+// k×k upper triangular factor r of a matrix whose largest dimension is
+// size are negligible relative to the largest of them.
+func fullRank(r []float64, k, size int) bool {
+	var largest float64
+	for i := 0; i < k; i++ {
+		largest = math.Max(largest, math.Abs(r[i*k+i]))
+	}
+
+	tol := largest * float64(size) * epsilon
+	for i := 0; i < k; i++ {
+		if math.Abs(r[i*k+i]) <= tol {
+			return false
+		}
+	}
+
+	return true
+}
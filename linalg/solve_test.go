@@ -0,0 +1,68 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+func TestSolve(t *testing.T) {
+	a := square()
+
+	tests := []struct {
+		name string
+		b    *tensor.Tensor[float64]
+		ax   func(x *tensor.Tensor[float64]) *tensor.Tensor[float64]
+	}{
+		{"matrices", tensor.Range[float64](0, 12, 1).Reshape(2, 3, 2), func(x *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+			return tensor.MatMul(a, x)
+		}},
+		{"broadcasted matrix", tensor.Range[float64](-3, 3, 1).Reshape(3, 2), func(x *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+			return tensor.MatMul(a, x)
+		}},
+		{"vector", tensor.From[float64]([]float64{1, -2, 3}), func(x *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+			return tensor.MatMul(a, x.Unsqueeze(x.Rank())).Squeeze(x.Rank())
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := Solve(a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := tt.b.Expand(tt.ax(x).Shape()...)
+			if got := tt.ax(x); !near(got, want, tol) {
+				t.Errorf("a·x: got %v, want %v", got, want)
+			}
+		})
+	}
+
+	singular := tensor.From[float64]([][]float64{{1, 2}, {2, 4}})
+	if _, err := Solve(singular, tensor.Ones[float64](2)); !errors.Is(err, ErrSingular) {
+		t.Errorf("singular: got %v, want %v", err, ErrSingular)
+	}
+
+	if _, err := Solve(a, tensor.Ones[float64](4)); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("mismatch: got %v, want %v", err, ErrShapeMismatch)
+	}
+}
+
+func TestInv(t *testing.T) {
+	a := square()
+
+	inv, err := Inv(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tensor.MatMul(a, inv); !near(got, eye(3, 2), tol) {
+		t.Errorf("a·a⁻¹: got %v, want the identity", got)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// A stack holds a stack of m×n matrices
+// as row-major float64 buffers.
+type stack struct {
+	batch []int // the shape of the leading batch axes
+	m, n  int
+	mats  [][]float64
+}
+
+// newStack returns a stack of zeroed m×n matrices
+// with the given batch shape.
+func newStack(batch []int, m, n int) *stack {
+	s := &stack{
+		batch: batch,
+		m:     m,
+		n:     n,
+	}
+
+	size := 1
+	for _, d := range batch {
+		size *= d
+	}
+
+	s.mats = make([][]float64, size)
+	for i := range s.mats {
+		s.mats[i] = make([]float64, m*n)
+	}
+
+	return s
+}
+
+// unstack copies the matrices held in the last two axes of
+// the Tensor into a stack.
+func unstack[T nune.Float](t *tensor.Tensor[T]) (*stack, error) {
+	if t.Rank() < 2 {
+		return nil, ErrNotMatrix
+	}
+
+	shape := t.Shape()
+	s := newStack(shape[:len(shape)-2], shape[len(shape)-2], shape[len(shape)-1])
+
+	data := t.Ravel()
+	for i, mat := range s.mats {
+		for j := range mat {
+			mat[j] = float64(data[i*len(mat)+j])
+		}
+	}
+
+	return s, nil
+}
+
+// unstackSquare is like unstack, but requires the matrices to be square.
+func unstackSquare[T nune.Float](t *tensor.Tensor[T]) (*stack, error) {
+	s, err := unstack(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.m != s.n {
+		return nil, ErrNotSquare
+	}
+
+	return s, nil
+}
+
+// unstackPair unstacks the two Tensors after broadcasting
+// their batch axes together.
+func unstackPair[T nune.Float](a, b *tensor.Tensor[T]) (*stack, *stack, error) {
+	if a.Rank() < 2 || b.Rank() < 2 {
+		return nil, nil, ErrNotMatrix
+	}
+
+	sa, sb := a.Shape(), b.Shape()
+	ba, bb := sa[:len(sa)-2], sb[:len(sb)-2]
+
+	batch, ok := broadcast(ba, bb)
+	if !ok {
+		return nil, nil, ErrShapeMismatch
+	}
+
+	a = a.BroadcastTo(append(append([]int{}, batch...), sa[len(sa)-2:]...)...)
+	b = b.BroadcastTo(append(append([]int{}, batch...), sb[len(sb)-2:]...)...)
+
+	x, _ := unstack(a)
+	y, _ := unstack(b)
+
+	return x, y, nil
+}
+
+// broadcast returns the shape resulting from broadcasting the
+// two shapes together, and whether or not they could be.
+func broadcast(a, b []int) ([]int, bool) {
+	shape, err := tensor.TryBroadcastShapes(a, b)
+	return shape, err == nil
+}
+
+// restack copies the matrices of the stack into a new Tensor.
+func restack[T nune.Float](s *stack) *tensor.Tensor[T] {
+	data := make([]T, 0, len(s.mats)*s.m*s.n)
+	for _, mat := range s.mats {
+		for _, x := range mat {
+			data = append(data, T(x))
+		}
+	}
+
+	shape := append(append([]int{}, s.batch...), s.m, s.n)
+
+	return tensor.FromBuffer(data, shape...)
+}
+
+// scalars copies one value per matrix of the stack into a new Tensor
+// whose shape is the stack's batch shape.
+func scalars[T nune.Float](batch []int, xs []float64) *tensor.Tensor[T] {
+	data := make([]T, len(xs))
+	for i, x := range xs {
+		data[i] = T(x)
+	}
+
+	return tensor.FromBuffer(data, batch...)
+}
+
+// vectors copies the vectors of a batch into a new Tensor whose
+// shape is the batch shape followed by the vectors' length.
+func vectors[T nune.Float](batch []int, vs [][]float64) *tensor.Tensor[T] {
+	data := make([]T, 0, len(vs)*len(vs[0]))
+	for _, v := range vs {
+		for _, x := range v {
+			data = append(data, T(x))
+		}
+	}
+
+	shape := append(append([]int{}, batch...), len(vs[0]))
+
+	return tensor.FromBuffer(data, shape...)
+}
@@ -0,0 +1,278 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// SVD computes the singular value decomposition of the matrices of the
+// Tensor through the one-sided Jacobi algorithm, such that a = u·diag(s)·vt,
+// where the singular values s are in descending order.
+//
+// If full is true, u and vt are square m×m and n×n orthogonal matrices.
+// Otherwise, the economy decomposition is returned, where u is an m×k
+// matrix and vt a k×n matrix with orthonormal columns and rows,
+// k being the least of m and n.
+func SVD[T nune.Float](a *tensor.Tensor[T], full bool) (u, s, vt *tensor.Tensor[T], err error) {
+	st, err := unstack(a)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	m, n := st.m, st.n
+	k := min(m, n)
+
+	cu, cv := k, k
+	if full {
+		cu, cv = m, n
+	}
+
+	us, vts := newStack(st.batch, m, cu), newStack(st.batch, cv, n)
+	ss := make([][]float64, len(st.mats))
+
+	for b, mat := range st.mats {
+		us.mats[b], ss[b], vts.mats[b] = svd(mat, m, n, full)
+	}
+
+	return restack[T](us), vectors[T](st.batch, ss), restack[T](vts), nil
+}
+
+// PInv returns the Moore-Penrose pseudo-inverses of the matrices
+// of the Tensor, computed through their singular value decompositions.
+// Singular values smaller than max(m, n)·ε times the largest one
+// are treated as zero.
+func PInv[T nune.Float](a *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	st, err := unstack(a)
+	if err != nil {
+		return nil, err
+	}
+
+	m, n := st.m, st.n
+	k := min(m, n)
+	pinv := newStack(st.batch, n, m)
+
+	for b, mat := range st.mats {
+		u, s, vt := svd(mat, m, n, false)
+		tol := tolerance(s, m, n)
+
+		for l := 0; l < k; l++ {
+			if s[l] <= tol {
+				continue
+			}
+
+			for i := 0; i < n; i++ {
+				x := vt[l*n+i] / s[l]
+				for j := 0; j < m; j++ {
+					pinv.mats[b][i*m+j] += x * u[j*k+l]
+				}
+			}
+		}
+	}
+
+	return restack[T](pinv), nil
+}
+
+// MatrixRank returns the ranks of the matrices of the Tensor,
+// in a Tensor whose shape is the Tensor's batch shape. The rank is the
+// number of singular values greater than max(m, n)·ε times the largest one.
+func MatrixRank[T nune.Float](a *tensor.Tensor[T]) (*tensor.Tensor[int], error) {
+	st, err := unstack(a)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make([]int, len(st.mats))
+	for b, mat := range st.mats {
+		_, s, _ := svd(mat, st.m, st.n, false)
+		tol := tolerance(s, st.m, st.n)
+
+		for _, x := range s {
+			if x > tol {
+				ranks[b]++
+			}
+		}
+	}
+
+	return tensor.FromBuffer(ranks, st.batch...), nil
+}
+
+// tolerance returns the threshold under which the singular values
+// of an m×n matrix are considered null.
+func tolerance(s []float64, m, n int) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	return s[0] * float64(max(m, n)) * epsilon
+}
+
+// svd returns the singular value decomposition of the m×n matrix as
+// row-major buffers, the singular values being in descending order.
+// If full is true, u is m×m and vt n×n, otherwise u is m×k and vt k×n,
+// k being the least of m and n.
+func svd(a []float64, m, n int, full bool) (u, s, vt []float64) {
+	if m < n {
+		at := transpose(a, m, n)
+
+		// a = (atᵀ) = (u'·s·vt')ᵀ = vt'ᵀ·s·u'ᵀ
+		u2, s, vt2 := svd(at, n, m, full)
+
+		cu := m
+		cv := m
+		if full {
+			cv = n
+		}
+
+		return transpose(vt2, m, cu), s, transpose(u2, n, cv)
+	}
+
+	w := make([]float64, m*n)
+	copy(w, a)
+
+	v := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+	}
+
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		rotated := false
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < m; i++ {
+					x, y := w[i*n+p], w[i*n+q]
+					alpha += x * x
+					beta += y * y
+					gamma += x * y
+				}
+
+				if gamma == 0 || math.Abs(gamma) <= epsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+
+				rotateCols(w, m, n, p, q, c, c*t)
+				rotateCols(v, n, n, p, q, c, c*t)
+			}
+		}
+
+		if !rotated {
+			break
+		}
+	}
+
+	norms := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			norms[j] += w[i*n+j] * w[i*n+j]
+		}
+		norms[j] = math.Sqrt(norms[j])
+	}
+
+	order := argsort(norms)
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+
+	cu := n
+	if full {
+		cu = m
+	}
+
+	s = make([]float64, n)
+	u = make([]float64, m*cu)
+	vt = make([]float64, n*n)
+	valid := make([]bool, cu)
+
+	for l, o := range order {
+		s[l] = norms[o]
+
+		for i := 0; i < n; i++ {
+			vt[l*n+i] = v[i*n+o]
+		}
+
+		if s[l] == 0 {
+			continue
+		}
+
+		for i := 0; i < m; i++ {
+			u[i*cu+l] = w[i*n+o] / s[l]
+		}
+		valid[l] = true
+	}
+
+	completeBasis(u, m, cu, valid)
+
+	return u, s, vt
+}
+
+// completeBasis replaces the columns of the m×c matrix u which aren't
+// valid by unit vectors orthogonal to all others, assuming the valid
+// columns are orthonormal.
+func completeBasis(u []float64, m, c int, valid []bool) {
+	candidate := 0
+
+	for j := 0; j < c; j++ {
+		if valid[j] {
+			continue
+		}
+
+		for ; candidate < m; candidate++ {
+			x := make([]float64, m)
+			x[candidate] = 1
+
+			// orthogonalize twice for numerical stability
+			for pass := 0; pass < 2; pass++ {
+				for l := 0; l < c; l++ {
+					if !valid[l] {
+						continue
+					}
+
+					var d float64
+					for i := 0; i < m; i++ {
+						d += u[i*c+l] * x[i]
+					}
+					for i := 0; i < m; i++ {
+						x[i] -= d * u[i*c+l]
+					}
+				}
+			}
+
+			if nx := norm(x); nx > 1e-8 {
+				for i := 0; i < m; i++ {
+					u[i*c+j] = x[i] / nx
+				}
+				valid[j] = true
+				candidate++
+
+				break
+			}
+		}
+	}
+}
+
+// transpose returns the transpose of the m×n matrix.
+func transpose(a []float64, m, n int) []float64 {
+	t := make([]float64, n*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			t[j*m+i] = a[i*n+j]
+		}
+	}
+
+	return t
+}
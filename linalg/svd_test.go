@@ -0,0 +1,77 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+func TestSVD(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *tensor.Tensor[float64]
+	}{
+		{"batch", square()},
+		{"tall", tall()},
+		{"wide", wide()},
+		{"rank deficient", tensor.From[float64]([][]float64{{1, 2, 3}, {2, 4, 6}, {1, 1, 1}, {0, 0, 0}})},
+	}
+
+	for _, tt := range tests {
+		for _, full := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				u, s, vt, err := SVD(tt.a, full)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r := tt.a.Rank()
+				m, n := tt.a.Size(r-2), tt.a.Size(r-1)
+				batch := tt.a.Shape()[:r-2]
+
+				if got := tensor.MatMul(mT(u), u); !near(got, eye(u.Size(r-1), batch...), tol) {
+					t.Errorf("full: %v: uᵀ·u: got %v, want the identity", full, got)
+				}
+				if got := tensor.MatMul(vt, mT(vt)); !near(got, eye(vt.Size(r-2), batch...), tol) {
+					t.Errorf("full: %v: vt·vtᵀ: got %v, want the identity", full, got)
+				}
+
+				k := s.Size(s.Rank() - 1)
+				uk := u.SliceAxis(r-1, 0, k, 1)
+				vk := vt.SliceAxis(r-2, 0, k, 1)
+
+				if got := tensor.MatMul(tensor.Mul(uk, s.Unsqueeze(s.Rank()-1)), vk); !near(got, tt.a, tol) {
+					t.Errorf("full: %v: u·diag(s)·vt: got %v, want %v", full, got, tt.a)
+				}
+
+				if full && (u.Size(r-1) != m || vt.Size(r-2) != n) {
+					t.Errorf("got shapes %v and %v, want square factors", u.Shape(), vt.Shape())
+				}
+
+				sv := s.Ravel()
+				for i := 1; i < len(sv); i++ {
+					if i%k != 0 && sv[i] > sv[i-1] || sv[i] < 0 {
+						t.Fatalf("s: %v is not in descending order", s)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPInv(t *testing.T) {
+	a := tall()
+
+	p, err := PInv(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tensor.MatMul(a, tensor.MatMul(p, a)); !near(got, a, tol) {
+		t.Errorf("a·a⁺·a: got %v, want %v", got, a)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune/internal/slice"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// tol is the tolerance of the reconstruction checks.
+const tol = 1e-9
+
+// near returns whether or not the two Tensors have the same shape,
+// and elements which differ by no more than tol.
+func near(a, b *tensor.Tensor[float64], tol float64) bool {
+	if a == nil || b == nil || !slice.Equal(a.Shape(), b.Shape()) {
+		return false
+	}
+
+	x, y := a.Ravel(), b.Ravel()
+	for i := range x {
+		if math.Abs(x[i]-y[i]) > tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mT returns a view over the Tensor with its matrices transposed.
+func mT(a *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+	return a.SwapAxes(a.Rank()-2, a.Rank()-1)
+}
+
+// eye returns a stack of n×n identity matrices with the given batch shape.
+func eye(n int, batch ...int) *tensor.Tensor[float64] {
+	data := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		data[i*n+i] = 1
+	}
+
+	return tensor.FromBuffer(data, n, n).Expand(append(batch, n, n)...).Contiguous()
+}
+
+// square returns a batch of 3×3 matrices, the first of which
+// is symmetric positive-definite and the second needs pivoting.
+func square() *tensor.Tensor[float64] {
+	return tensor.From[float64]([][][]float64{
+		{{4, 1, 2}, {1, 5, 3}, {2, 3, 6}},
+		{{0, 2, 1}, {3, -1, 4}, {1, 1, 1}},
+	})
+}
+
+// wide and tall return rectangular matrices.
+func wide() *tensor.Tensor[float64] {
+	return tensor.From[float64]([][]float64{
+		{1, 2, 0, -1, 3},
+		{0, 1, 4, 2, -2},
+		{5, -3, 1, 1, 0},
+	})
+}
+
+func tall() *tensor.Tensor[float64] {
+	return mT(wide()).Contiguous()
+}
@@ -10,3 +10,18 @@ type Numeric interface {
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
 		~float32 | ~float64
 }
+
+// Float is the set of all floating-point types and their supersets.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Complex is the set of all complex types and their supersets.
+type Complex interface {
+	~complex64 | ~complex128
+}
+
+// Number is the set of all numeric and complex types and their supersets.
+type Number interface {
+	Numeric | Complex
+}
@@ -0,0 +1,13 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package safetensors implements loading and saving Tensors
+// in the safetensors format.
+//
+// Loaded files are memory-mapped where the platform allows it, and their
+// Tensors are created lazily on access. A Tensor whose type matches the
+// stored dtype wraps the mapped memory directly, without copying it,
+// and is therefore read-only and only valid until its File is closed,
+// while GetCopy returns Tensors which outlive it.
+package safetensors
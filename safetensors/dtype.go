@@ -0,0 +1,147 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safetensors
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"unsafe"
+
+	"github.com/lordlarker/nune"
+)
+
+// A dtype describes how the elements of a Tensor are stored.
+type dtype struct {
+	width int          // the size of an element in bytes
+	kind  reflect.Kind // the kind of the Go type sharing the dtype's representation, if any
+}
+
+// dtypes lists the supported dtypes by name.
+var dtypes = map[string]dtype{
+	"BOOL": {1, reflect.Uint8},
+	"U8":   {1, reflect.Uint8},
+	"I8":   {1, reflect.Int8},
+	"U16":  {2, reflect.Uint16},
+	"I16":  {2, reflect.Int16},
+	"F16":  {2, reflect.Invalid},
+	"BF16": {2, reflect.Invalid},
+	"U32":  {4, reflect.Uint32},
+	"I32":  {4, reflect.Int32},
+	"F32":  {4, reflect.Float32},
+	"U64":  {8, reflect.Uint64},
+	"I64":  {8, reflect.Int64},
+	"F64":  {8, reflect.Float64},
+}
+
+// littleEndian reports whether or not the host is little-endian,
+// as the dtypes are.
+var littleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+// native returns whether or not the elements of the type T share
+// the in-memory representation of the given dtype.
+func native[T nune.Numeric](dt dtype) bool {
+	k, size := reflect.TypeOf(T(0)).Kind(), unsafe.Sizeof(T(0))
+
+	// int and uint share the representation of their sized equivalents
+	switch {
+	case k == reflect.Int && size == 8:
+		k = reflect.Int64
+	case k == reflect.Int && size == 4:
+		k = reflect.Int32
+	case k == reflect.Uint && size == 8:
+		k = reflect.Uint64
+	case k == reflect.Uint && size == 4:
+		k = reflect.Uint32
+	}
+
+	return littleEndian && k == dt.kind
+}
+
+// decoder returns a function decoding an element of the
+// dtype of the given name into the type T.
+func decoder[T nune.Numeric](name string) func([]byte) T {
+	le := binary.LittleEndian
+
+	switch name {
+	case "BOOL", "U8":
+		return func(b []byte) T { return T(b[0]) }
+	case "I8":
+		return func(b []byte) T { return T(int8(b[0])) }
+	case "U16":
+		return func(b []byte) T { return T(le.Uint16(b)) }
+	case "I16":
+		return func(b []byte) T { return T(int16(le.Uint16(b))) }
+	case "F16":
+		return func(b []byte) T { return T(halfToFloat(le.Uint16(b))) }
+	case "BF16":
+		return func(b []byte) T { return T(math.Float32frombits(uint32(le.Uint16(b)) << 16)) }
+	case "U32":
+		return func(b []byte) T { return T(le.Uint32(b)) }
+	case "I32":
+		return func(b []byte) T { return T(int32(le.Uint32(b))) }
+	case "F32":
+		return func(b []byte) T { return T(math.Float32frombits(le.Uint32(b))) }
+	case "U64":
+		return func(b []byte) T { return T(le.Uint64(b)) }
+	case "I64":
+		return func(b []byte) T { return T(int64(le.Uint64(b))) }
+	default:
+		return func(b []byte) T { return T(math.Float64frombits(le.Uint64(b))) }
+	}
+}
+
+// encoder returns the name of the dtype matching the type T,
+// along with a function encoding an element of type T.
+// Types of platform-dependent size, such as int, are stored in 64 bits.
+func encoder[T nune.Numeric]() (string, func([]byte, T)) {
+	le := binary.LittleEndian
+
+	switch reflect.TypeOf(T(0)).Kind() {
+	case reflect.Uint8:
+		return "U8", func(b []byte, x T) { b[0] = byte(x) }
+	case reflect.Int8:
+		return "I8", func(b []byte, x T) { b[0] = byte(x) }
+	case reflect.Uint16:
+		return "U16", func(b []byte, x T) { le.PutUint16(b, uint16(x)) }
+	case reflect.Int16:
+		return "I16", func(b []byte, x T) { le.PutUint16(b, uint16(x)) }
+	case reflect.Uint32:
+		return "U32", func(b []byte, x T) { le.PutUint32(b, uint32(x)) }
+	case reflect.Int32:
+		return "I32", func(b []byte, x T) { le.PutUint32(b, uint32(x)) }
+	case reflect.Float32:
+		return "F32", func(b []byte, x T) { le.PutUint32(b, math.Float32bits(float32(x))) }
+	case reflect.Uint, reflect.Uint64:
+		return "U64", func(b []byte, x T) { le.PutUint64(b, uint64(x)) }
+	case reflect.Int, reflect.Int64:
+		return "I64", func(b []byte, x T) { le.PutUint64(b, uint64(x)) }
+	default:
+		return "F64", func(b []byte, x T) { le.PutUint64(b, math.Float64bits(float64(x))) }
+	}
+}
+
+// halfToFloat converts an IEEE 754 half-precision float to a float32.
+func halfToFloat(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+
+	switch exp {
+	case 0x1f: // infinities and NaNs
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case 0: // zeros and subnormals
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safetensors
+
+import "errors"
+
+// List of errors.
+var (
+	// ErrBadHeader occurs when a file's header is malformed
+	// or doesn't match the size of its data.
+	ErrBadHeader = errors.New("nune/safetensors: malformed header")
+
+	// ErrDtype occurs when a dtype is unknown, or when
+	// a Tensor's type has no matching dtype.
+	ErrDtype = errors.New("nune/safetensors: unsupported dtype")
+
+	// ErrNotFound occurs when a File holds no Tensor of the given name.
+	ErrNotFound = errors.New("nune/safetensors: no such Tensor")
+
+	// ErrEmpty occurs when getting a Tensor stored with
+	// a null dimension, since Tensors can't be empty.
+	ErrEmpty = errors.New("nune/safetensors: Tensor has no elements")
+
+	// ErrClosed occurs when accessing a closed File.
+	ErrClosed = errors.New("nune/safetensors: file already closed")
+)
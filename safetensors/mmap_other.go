@@ -0,0 +1,28 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+
+package safetensors
+
+import (
+	"io"
+	"os"
+)
+
+// mmap reads the given file into memory,
+// as memory mapping isn't supported on this platform.
+func mmap(f *os.File, size int) ([]byte, error) {
+	b := make([]byte, size)
+	if _, err := io.ReadFull(f, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// munmap releases memory returned by mmap.
+func munmap(b []byte) error {
+	return nil
+}
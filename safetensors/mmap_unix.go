@@ -0,0 +1,22 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package safetensors
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmap maps the given file into memory, read-only.
+func mmap(f *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// munmap unmaps memory returned by mmap.
+func munmap(b []byte) error {
+	return syscall.Munmap(b)
+}
@@ -0,0 +1,377 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safetensors
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"unsafe"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/tensor"
+)
+
+// metadataKey is the header entry holding the free-form metadata.
+const metadataKey = "__metadata__"
+
+// An entry describes a Tensor stored in a file.
+type entry struct {
+	Dtype   string `json:"dtype"`
+	Shape   []int  `json:"shape"`
+	Offsets [2]int `json:"data_offsets"` // bounds of the Tensor's bytes within the data section
+}
+
+// A File is a loaded safetensors file, whose Tensors are created on access.
+type File struct {
+	mapped   []byte // the whole file, memory-mapped
+	data     []byte // the data section of the file
+	entries  map[string]entry
+	metadata map[string]string
+}
+
+// Load memory-maps the safetensors file at the given path and parses its
+// header. The File must be closed once its Tensors are no longer used.
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := int(info.Size())
+	if size < 8 {
+		return nil, fmt.Errorf("%w: file too short", ErrBadHeader)
+	}
+
+	mapped, err := mmap(f, size)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parse(mapped)
+	if err != nil {
+		munmap(mapped)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// parse parses the header of the given file's content.
+func parse(mapped []byte) (*File, error) {
+	n := binary.LittleEndian.Uint64(mapped)
+	if n > uint64(len(mapped)-8) {
+		return nil, fmt.Errorf("%w: header exceeds the file", ErrBadHeader)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(mapped[8:8+n], &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadHeader, err)
+	}
+
+	file := &File{
+		mapped:  mapped,
+		data:    mapped[8+n:],
+		entries: make(map[string]entry, len(raw)),
+	}
+
+	for name, msg := range raw {
+		if name == metadataKey {
+			if err := json.Unmarshal(msg, &file.metadata); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrBadHeader, err)
+			}
+			continue
+		}
+
+		var e entry
+		if err := json.Unmarshal(msg, &e); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadHeader, err)
+		}
+
+		dt, ok := dtypes[e.Dtype]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrDtype, e.Dtype)
+		}
+
+		numel, ok := elements(e.Shape, len(file.data)/dt.width)
+		if !ok {
+			return nil, fmt.Errorf("%w: bad shape %v of %q", ErrBadHeader, e.Shape, name)
+		}
+
+		begin, end := e.Offsets[0], e.Offsets[1]
+		if begin < 0 || end < begin || end > len(file.data) || end-begin != numel*dt.width {
+			return nil, fmt.Errorf("%w: bad offsets %v of %q", ErrBadHeader, e.Offsets, name)
+		}
+
+		file.entries[name] = e
+	}
+
+	return file, nil
+}
+
+// elements returns the number of elements of the given shape, and
+// whether or not it is a valid shape of no more than max elements.
+// The number is bounded as it is computed, such that it can't overflow.
+func elements(shape []int, max int) (int, bool) {
+	for _, d := range shape {
+		if d < 0 {
+			return 0, false
+		} else if d == 0 {
+			return 0, true // whatever the other dimensions
+		}
+	}
+
+	numel := 1
+	for _, d := range shape {
+		if d > max/numel {
+			return 0, false
+		}
+		numel *= d
+	}
+
+	return numel, true
+}
+
+// Close unmaps the File. The Tensors wrapping its memory, which are
+// returned by Get, must not be used afterwards, unlike those returned
+// by GetCopy.
+func (f *File) Close() error {
+	if f.mapped == nil {
+		return ErrClosed
+	}
+
+	err := munmap(f.mapped)
+	f.mapped, f.data = nil, nil
+
+	return err
+}
+
+// Names returns the names of the Tensors of the File, in sorted order.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.entries))
+	for name := range f.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Metadata returns the free-form metadata of the File.
+func (f *File) Metadata() map[string]string {
+	m := make(map[string]string, len(f.metadata))
+	for k, v := range f.metadata {
+		m[k] = v
+	}
+
+	return m
+}
+
+// Dtype returns the name of the dtype of the Tensor of the given name,
+// such as "F32", or an empty string if there is none.
+func (f *File) Dtype(name string) string {
+	return f.entries[name].Dtype
+}
+
+// Shape returns the shape of the Tensor of the given name,
+// or nil if there is none.
+func (f *File) Shape(name string) []int {
+	e, ok := f.entries[name]
+	if !ok {
+		return nil
+	}
+
+	return append([]int{}, e.Shape...)
+}
+
+// Get returns the Tensor of the given name from the File. Tensors
+// stored with a null dimension, which are valid in the format but
+// can't be represented by Tensors, are reported with ErrEmpty.
+//
+// If the type T shares the representation of the stored dtype, the
+// Tensor wraps the File's memory without copying it, and is read-only
+// and only valid until the File is closed. Otherwise, its elements are
+// converted to T as tensor.Cast would, half-precision dtypes being
+// decoded as float32 first.
+func Get[T nune.Numeric](f *File, name string) (*tensor.Tensor[T], error) {
+	return get[T](f, name, false)
+}
+
+// GetCopy returns the Tensor of the given name from the File as Get
+// does, but always copies its elements into a regular, writable Tensor,
+// which remains valid once the File is closed.
+func GetCopy[T nune.Numeric](f *File, name string) (*tensor.Tensor[T], error) {
+	return get[T](f, name, true)
+}
+
+// get returns the Tensor of the given name from the File,
+// wrapping the File's memory unless copied is true.
+func get[T nune.Numeric](f *File, name string, copied bool) (*tensor.Tensor[T], error) {
+	if f.mapped == nil {
+		return nil, ErrClosed
+	}
+
+	e, ok := f.entries[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+
+	dt := dtypes[e.Dtype]
+	raw := f.data[e.Offsets[0]:e.Offsets[1]]
+	n := len(raw) / dt.width
+
+	if n == 0 {
+		return nil, fmt.Errorf("%w: %q of shape %v", ErrEmpty, name, e.Shape)
+	}
+
+	// the elements must be aligned to be read in place
+	aligned := uintptr(unsafe.Pointer(&raw[0]))%unsafe.Alignof(T(0)) == 0
+
+	if native[T](dt) && aligned && !copied {
+		data := unsafe.Slice((*T)(unsafe.Pointer(&raw[0])), n)
+		return tensor.FromExternal(data, e.Shape...), nil
+	}
+
+	decode := decoder[T](e.Dtype)
+
+	data := make([]T, n)
+	for i := range data {
+		data[i] = decode(raw[i*dt.width:])
+	}
+
+	return tensor.FromBuffer(data, e.Shape...), nil
+}
+
+// Save writes the given Tensors, by name, in the safetensors format to
+// the file at the given path. The Tensors may be of any numeric type,
+// those of types of platform-dependent size, such as int, being stored
+// with 64-bit dtypes.
+func Save(path string, tensors map[string]any) error {
+	names := make([]string, 0, len(tensors))
+	header := make(map[string]entry, len(tensors))
+	blobs := make(map[string][]byte, len(tensors))
+
+	for name, t := range tensors {
+		if name == metadataKey {
+			return fmt.Errorf("%w: reserved name %q", ErrBadHeader, name)
+		}
+
+		dtype, shape, blob, err := encode(t)
+		if err != nil {
+			return fmt.Errorf("%w (%q)", err, name)
+		}
+
+		names = append(names, name)
+		header[name] = entry{Dtype: dtype, Shape: shape}
+		blobs[name] = blob
+	}
+
+	// storing the widest dtypes first keeps every Tensor aligned
+	sort.Slice(names, func(i, j int) bool {
+		wi, wj := dtypes[header[names[i]].Dtype].width, dtypes[header[names[j]].Dtype].width
+		if wi != wj {
+			return wi > wj
+		}
+		return names[i] < names[j]
+	})
+
+	var offset int
+	for _, name := range names {
+		e := header[name]
+		e.Offsets = [2]int{offset, offset + len(blobs[name])}
+		header[name] = e
+
+		offset += len(blobs[name])
+	}
+
+	h, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	// the header is padded with spaces to align the data section
+	h = append(h, bytes.Repeat([]byte{' '}, (8-len(h)%8)%8)...)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	binary.Write(w, binary.LittleEndian, uint64(len(h)))
+	w.Write(h)
+	for _, name := range names {
+		w.Write(blobs[name])
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// encode returns the dtype, shape and bytes of the given Tensor.
+func encode(v any) (string, []int, []byte, error) {
+	switch t := v.(type) {
+	case *tensor.Tensor[int]:
+		return encodeTensor(t)
+	case *tensor.Tensor[int8]:
+		return encodeTensor(t)
+	case *tensor.Tensor[int16]:
+		return encodeTensor(t)
+	case *tensor.Tensor[int32]:
+		return encodeTensor(t)
+	case *tensor.Tensor[int64]:
+		return encodeTensor(t)
+	case *tensor.Tensor[uint]:
+		return encodeTensor(t)
+	case *tensor.Tensor[uint8]:
+		return encodeTensor(t)
+	case *tensor.Tensor[uint16]:
+		return encodeTensor(t)
+	case *tensor.Tensor[uint32]:
+		return encodeTensor(t)
+	case *tensor.Tensor[uint64]:
+		return encodeTensor(t)
+	case *tensor.Tensor[float32]:
+		return encodeTensor(t)
+	case *tensor.Tensor[float64]:
+		return encodeTensor(t)
+	default:
+		return "", nil, nil, fmt.Errorf("%w: %T", ErrDtype, v)
+	}
+}
+
+// encodeTensor returns the dtype, shape and bytes of the given Tensor.
+func encodeTensor[T nune.Numeric](t *tensor.Tensor[T]) (string, []int, []byte, error) {
+	dtype, enc := encoder[T]()
+	width := dtypes[dtype].width
+
+	data := t.Ravel()
+
+	b := make([]byte, len(data)*width)
+	for i, x := range data {
+		enc(b[i*width:], x)
+	}
+
+	shape := t.Shape()
+	if shape == nil {
+		shape = []int{} // stored as an empty list rather than null
+	}
+
+	return dtype, shape, b, nil
+}
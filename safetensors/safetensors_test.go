@@ -0,0 +1,181 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safetensors
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lordlarker/nune/tensor"
+)
+
+// writeRaw writes a safetensors file made of the given header and data.
+func writeRaw(t *testing.T, header string, data []byte) string {
+	t.Helper()
+
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(len(header)))
+	buf = append(append(buf, header...), data...)
+
+	p := filepath.Join(t.TempDir(), "raw.safetensors")
+	if err := os.WriteFile(p, buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestRoundTrip(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "m.safetensors")
+	err := Save(p, map[string]any{
+		"w": tensor.Range[float32](0, 12, 1).Reshape(3, 4),
+		"b": tensor.Range[int](-2, 3, 1),
+		"s": tensor.From[float64](3.5),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w, err := Get[float32](f, "w")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.IsReadOnly() || w.Sum() != 66 || len(w.Shape()) != 2 {
+		t.Errorf("w: got %v (read-only: %v)", w, w.IsReadOnly())
+	}
+
+	wd, err := Get[float64](f, "w")
+	if err != nil || wd.IsReadOnly() || wd.Sum() != 66 {
+		t.Errorf("w as float64: got %v, %v", wd, err)
+	}
+
+	b, err := Get[int](f, "b")
+	if err != nil || b.Sum() != 0 || b.Size(0) != 5 {
+		t.Errorf("b: got %v, %v", b, err)
+	}
+
+	s, err := Get[float64](f, "s")
+	if err != nil || s.Rank() != 0 || s.Ravel()[0] != 3.5 {
+		t.Errorf("s: got %v, %v", s, err)
+	}
+
+	if _, err := Get[int](f, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing: got %v", err)
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		data   []byte
+		err    error
+	}{
+		{"bad json", `{"x":`, nil, ErrBadHeader},
+		{"unknown dtype", `{"x":{"dtype":"Q7","shape":[1],"data_offsets":[0,1]}}`, make([]byte, 1), ErrDtype},
+		{"negative dimension", `{"x":{"dtype":"F32","shape":[-1],"data_offsets":[0,4]}}`, make([]byte, 4), ErrBadHeader},
+		{"offsets past data", `{"x":{"dtype":"F32","shape":[2],"data_offsets":[0,8]}}`, make([]byte, 4), ErrBadHeader},
+		{"offsets mismatch shape", `{"x":{"dtype":"F32","shape":[2],"data_offsets":[0,4]}}`, make([]byte, 8), ErrBadHeader},
+		{"reversed offsets", `{"x":{"dtype":"F32","shape":[1],"data_offsets":[4,0]}}`, make([]byte, 4), ErrBadHeader},
+		{"overflowing shape", `{"x":{"dtype":"F32","shape":[4611686018427387904,4],"data_offsets":[0,0]}}`, nil, ErrBadHeader},
+		{"overflowing shape with data", `{"x":{"dtype":"F32","shape":[1152921504606846977,4],"data_offsets":[0,16]}}`, make([]byte, 16), ErrBadHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Load(writeRaw(t, tt.header, tt.data))
+			if err == nil {
+				f.Close()
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoadShortFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "short.safetensors")
+	if err := os.WriteFile(p, []byte{1, 2, 3}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(p); !errors.Is(err, ErrBadHeader) {
+		t.Fatalf("got %v, want %v", err, ErrBadHeader)
+	}
+
+	// a header longer than the file
+	raw := make([]byte, 8)
+	binary.LittleEndian.PutUint64(raw, 1<<40)
+	if err := os.WriteFile(p, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(p); !errors.Is(err, ErrBadHeader) {
+		t.Fatalf("got %v, want %v", err, ErrBadHeader)
+	}
+}
+
+func TestEmptyTensor(t *testing.T) {
+	header := `{"e":{"dtype":"F32","shape":[5,0],"data_offsets":[0,0]},` +
+		`"z":{"dtype":"I64","shape":[0],"data_offsets":[0,0]},` +
+		`"x":{"dtype":"F32","shape":[2],"data_offsets":[0,8]}}`
+
+	f, err := Load(writeRaw(t, header, make([]byte, 8)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, name := range []string{"e", "z"} {
+		if _, err := Get[float32](f, name); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%s: got %v, want %v", name, err, ErrEmpty)
+		}
+	}
+
+	if x, err := Get[float32](f, "x"); err != nil || x.Size() != 2 {
+		t.Errorf("x: got %v, %v", x, err)
+	}
+}
+
+func TestGetCopy(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "c.safetensors")
+	if err := Save(p, map[string]any{"w": tensor.Range[float32](0, 4, 1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := GetCopy[float32](f, "w")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the copy is usable once the File is closed
+	w.AddInPlace(tensor.Ones[float32](4))
+	if w.IsReadOnly() || w.Sum() != 10 {
+		t.Errorf("got %v (read-only: %v)", w, w.IsReadOnly())
+	}
+
+	if _, err := GetCopy[float32](f, "w"); !errors.Is(err, ErrClosed) {
+		t.Errorf("got %v, want %v", err, ErrClosed)
+	}
+}
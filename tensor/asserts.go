@@ -4,52 +4,19 @@
 
 package tensor
 
-import "errors"
-
-// List of errors.
-var (
-	// errBadShape occurs when a shape is nil or a has axes whose
-	// dimensions are less than or equal to zero.
-	errBadShape = errors.New("nune: received a bad shape")
-
-	// errAxisBounds occurs when an axis is out of
-	// (0, tensor rank) bounds.
-	errAxisBounds = errors.New("nune: axis out of bounds")
-
-	// errBadStep occurs when a step size is null or
-	// is opposite to the inverval's order.
-	errBadStep = errors.New("nune: received a bad step size")
-
-	// errBadInterval occurs when a null interval, or a descending
-	// interval, or an interval that doesn't fall within the allowed limits
-	// is provided to a function like range or slice.
-	errBadInterval = errors.New("nune: received a bad interval")
-
-	// errUnwrapBacking occurs when a backing could not be
-	// unwrapped into a 1-dimensional numeric buffer in order
-	// to create a Tensor.
-	errUnwrapBacking = errors.New("nune: could not unwrap backing to Tensor")
-
-	// errArgsBounds occurs when a function receives more arguments
-	// than it should.
-	errArgsBounds = errors.New("nune: received more arguments than allowed")
-
-	// errStorageDump occurs when the Assign method fails to dump
-	// the given data to the Tensor's storage.
-	errStorageDump = errors.New("nune: could not dump data buffer to storage")
-)
+import "github.com/lordlarker/nune"
 
 // assertGoodShape makes sure a shape isn't empty,
 // and that none of the shapes axes's dimensions
 // are less than or equal to zero, and panics otherwise.
 func assertGoodShape(s ...int) {
 	if len(s) == 0 {
-		panic(errBadShape)
+		panic(ErrBadShape)
 	}
 
 	for _, a := range s {
 		if a <= 0 {
-			panic(errBadShape)
+			panic(ErrBadShape)
 		}
 	}
 }
@@ -57,8 +24,26 @@ func assertGoodShape(s ...int) {
 // assertAxisBounds makes sure an axis is strictly positive
 // and is less than the Tensor's rank.
 func assertAxisBounds(axis, rank int) {
-	if axis < 0 || axis > rank {
-		panic(errAxisBounds)
+	if axis < 0 || axis >= rank {
+		panic(ErrAxisBounds)
+	}
+}
+
+// assertPermutation makes sure the given axes are a permutation
+// of the axes of a Tensor of the given rank.
+func assertPermutation(axes []int, rank int) {
+	if len(axes) != rank {
+		panic(ErrBadAxes)
+	}
+
+	seen := make([]bool, rank)
+	for _, a := range axes {
+		assertAxisBounds(a, rank)
+
+		if seen[a] {
+			panic(ErrBadAxes)
+		}
+		seen[a] = true
 	}
 }
 
@@ -66,9 +51,9 @@ func assertAxisBounds(axis, rank int) {
 // and whose sign matches the interval's order.
 func assertGoodStep(s, start, end int) {
 	if s == 0 {
-		panic(errBadStep)
+		panic(ErrBadStep)
 	} else if s > 0 && end < start || s < 0 && end > start {
-		panic(errBadStep)
+		panic(ErrBadStep)
 	}
 }
 
@@ -79,12 +64,12 @@ func assertGoodInterval(start, end int, limits ...[2]int) {
 	assertArgsBounds(len(limits), 1)
 
 	if start >= end {
-		panic(errBadInterval)
+		panic(ErrBadInterval)
 	}
 
 	if len(limits) == 1 {
 		if start < limits[0][0] || end > limits[0][1] {
-			panic(errBadInterval)
+			panic(ErrBadInterval)
 		}
 	}
 }
@@ -93,7 +78,7 @@ func assertGoodInterval(start, end int, limits ...[2]int) {
 // the interval [start, end), and panics otherwise.
 func assertInRange(x, start, end int) {
 	if x < start || x >= end {
-		panic("")
+		panic(ErrIndexBounds)
 	}
 }
 
@@ -101,6 +86,29 @@ func assertInRange(x, start, end int) {
 // doesn't exceed the number of allowed arguments.
 func assertArgsBounds(nargs, max int) {
 	if nargs > max {
-		panic(errArgsBounds)
+		panic(ErrArgsBounds)
+	}
+}
+
+// assertWritable makes sure the Tensor's storage can be written to,
+// and that none of its elements share the same storage position,
+// which concurrent writes would race over.
+func assertWritable[T nune.Numeric](t *Tensor[T]) {
+	if t.storage.ReadOnly() {
+		panic(ErrReadOnly)
+	}
+
+	if t.layout.Overlapping() {
+		panic(ErrOverlap)
+	}
+}
+
+// assertNonZero makes sure none of the Tensor's elements is zero,
+// such that it can be divided by.
+func assertNonZero[T nune.Numeric](t *Tensor[T]) {
+	for _, x := range t.flat() {
+		if x == 0 {
+			panic(ErrDivisionByZero)
+		}
 	}
 }
@@ -7,24 +7,29 @@ package tensor
 import (
 	"unsafe"
 
+	"github.com/lordlarker/nune/internal/cpd"
 	"github.com/lordlarker/nune/internal/slice"
 	"github.com/lordlarker/nune/internal/utils"
 )
 
-// Ravel returns a copy of the Tensor's 1-dimensional data buffer.
+// Ravel returns a copy of the Tensor's elements
+// as a 1-dimensional buffer, in row-major order.
 func (t *Tensor[T]) Ravel() []T {
-	return t.storage.Load()
+	data := slice.WithLen[T](t.Numel())
+	cpd.Copy(t.layout.Shape(), t.span(), cpd.Flat(data, t.layout.Shape()))
+
+	return data
 }
 
-// Numel returns the number of elements in the Tensor's data buffer.
+// Numel returns the number of elements in the Tensor.
 func (t *Tensor[T]) Numel() int {
-	return t.storage.Numel()
+	return t.layout.Numel()
 }
 
 // Numby returns the size in bytes occupied by all elements
-// of the Tensor's underlying data buffer.
+// of the Tensor.
 func (t *Tensor[T]) Numby() uintptr {
-	return t.storage.Numby()
+	return unsafe.Sizeof(T(0)) * uintptr(t.Numel())
 }
 
 // Rank returns the Tensor's rank
@@ -43,6 +48,24 @@ func (t *Tensor[T]) Strides() []int {
 	return slice.Copy(t.layout.Strides())
 }
 
+// Offset returns the position of the Tensor's first element
+// in its underlying storage.
+func (t *Tensor[T]) Offset() int {
+	return t.layout.Offset()
+}
+
+// IsContiguous returns whether or not the Tensor's elements
+// are laid out contiguously in row-major order in its storage.
+func (t *Tensor[T]) IsContiguous() bool {
+	return t.layout.Contiguous()
+}
+
+// IsReadOnly returns whether or not the Tensor is backed
+// by read-only, externally owned memory.
+func (t *Tensor[T]) IsReadOnly() bool {
+	return t.storage.ReadOnly()
+}
+
 // Size returns the Tensor's total shape size.
 // If axis is specified, the number of dimensions at
 // that axis is returned.
@@ -54,7 +77,7 @@ func (t *Tensor[T]) Size(axis ...int) int {
 		return slice.Prod(t.layout.Shape())
 	} else {
 		assertAxisBounds(axis[0], t.Rank())
-		return t.Shape()[axis[0]]
+		return t.layout.Shape()[axis[0]]
 	}
 }
 
@@ -71,30 +94,7 @@ func (t *Tensor[T]) MemSize() uintptr {
 // Broadable returns whether or not the Tensor can be
 // broadcasted to the given shape.
 func (t *Tensor[T]) Broadable(shape ...int) bool {
-	if utils.Panics(func() {
-		assertGoodShape(shape...)
-		assertArgsBounds(len(shape), t.Rank()-1)
-	}) {
-		return false
-	}
-
-	var s []int
-
-	if t.Rank() < len(shape) {
-		s = slice.WithLen[int](len(shape))
-		for i := 0; i < len(shape)-t.Rank(); i++ {
-			s[i] = 1
-		}
-		copy(s[len(shape)-t.Rank():], t.layout.Shape())
-	} else {
-		s = t.Shape()
-	}
-
-	for i := 0; i < len(shape); i++ {
-		if s[i] != shape[i] && s[i] != 1 {
-			return false
-		}
-	}
-
-	return true
+	return !utils.Panics(func() {
+		t.layout.Broadcast(shape)
+	})
 }
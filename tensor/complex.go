@@ -0,0 +1,255 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"math/cmplx"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// A ComplexTensor is a generic, n-dimensional tensor of complex numbers.
+// It shares the Tensor's storage and indexing scheme, such that
+// its views are created and walked the same way.
+type ComplexTensor[T nune.Complex] struct {
+	storage *cpd.Storage[T] // the storage that holds the ComplexTensor's data
+	layout  *layout         // the layout that holds the ComplexTensor's indexing scheme
+}
+
+// ComplexFromBuffer returns a ComplexTensor of the given shape
+// backed by the given buffer, without copying it.
+// The buffer holds the ComplexTensor's elements in row-major order.
+func ComplexFromBuffer[T nune.Complex](data []T, shape ...int) *ComplexTensor[T] {
+	if len(shape) != 0 {
+		assertGoodShape(shape...)
+	}
+
+	if len(data) != slice.Prod(shape) {
+		panic(shapeError("ComplexFromBuffer", shape, []int{len(data)}, ErrBadShape))
+	}
+
+	return &ComplexTensor[T]{
+		storage: cpd.NewStorage(data),
+		layout:  newLayout(slice.Copy(shape)),
+	}
+}
+
+// Complex returns a ComplexTensor whose real and imaginary parts
+// are the elements of the given Tensors, which must have the same shape.
+// If im is nil, the imaginary parts are zero.
+func Complex[C nune.Complex, T nune.Numeric](re, im *Tensor[T]) *ComplexTensor[C] {
+	if im != nil && !slice.Equal(re.layout.Shape(), im.layout.Shape()) {
+		panic(shapeError("Complex", re.layout.Shape(), im.layout.Shape(), ErrShapeMismatch))
+	}
+
+	r := re.flat()
+	data := slice.WithLen[C](len(r))
+
+	if im == nil {
+		for i, x := range r {
+			data[i] = C(complex(float64(x), 0))
+		}
+	} else {
+		for i, y := range im.flat() {
+			data[i] = C(complex(float64(r[i]), float64(y)))
+		}
+	}
+
+	return ComplexFromBuffer(data, re.Shape()...)
+}
+
+// CastToComplex casts a Tensor's elements to the given complex type,
+// with null imaginary parts.
+func CastToComplex[C nune.Complex, T nune.Numeric](t *Tensor[T]) *ComplexTensor[C] {
+	return Complex[C, T](t, nil)
+}
+
+// CastToReal casts a ComplexTensor's elements to the given numeric type,
+// discarding their imaginary parts.
+func CastToReal[T nune.Numeric, C nune.Complex](c *ComplexTensor[C]) *Tensor[T] {
+	data := c.flat()
+
+	r := slice.WithLen[T](len(data))
+	for i, x := range data {
+		r[i] = T(real(complex128(x)))
+	}
+
+	return FromBuffer(r, c.Shape()...)
+}
+
+// CastComplex casts a ComplexTensor's underlying type
+// to the given complex type.
+func CastComplex[D nune.Complex, C nune.Complex](c *ComplexTensor[C]) *ComplexTensor[D] {
+	data := c.flat()
+
+	d := slice.WithLen[D](len(data))
+	for i, x := range data {
+		d[i] = D(x)
+	}
+
+	return ComplexFromBuffer(d, c.Shape()...)
+}
+
+// span returns the strided span through which
+// the ComplexTensor walks its storage.
+func (c *ComplexTensor[T]) span() cpd.Span[T] {
+	return cpd.Span[T]{
+		Buf:     c.storage.Load(),
+		Strides: c.layout.Strides(),
+		Offset:  c.layout.Offset(),
+	}
+}
+
+// view returns a ComplexTensor sharing the ComplexTensor's
+// storage through the given layout.
+func (c *ComplexTensor[T]) view(l *layout) *ComplexTensor[T] {
+	return &ComplexTensor[T]{
+		storage: c.storage,
+		layout:  l,
+	}
+}
+
+// flat returns the ComplexTensor's elements in row-major order,
+// avoiding a copy when the ComplexTensor is contiguous.
+// The returned buffer must be treated as read-only.
+func (c *ComplexTensor[T]) flat() []T {
+	if c.layout.Contiguous() {
+		return c.storage.Slice(c.layout.Offset(), c.layout.Offset()+c.layout.Numel())
+	}
+
+	return c.Ravel()
+}
+
+// Ravel returns a copy of the ComplexTensor's elements
+// as a 1-dimensional buffer, in row-major order.
+func (c *ComplexTensor[T]) Ravel() []T {
+	data := slice.WithLen[T](c.Numel())
+	cpd.Copy(c.layout.Shape(), c.span(), cpd.Flat(data, c.layout.Shape()))
+
+	return data
+}
+
+// Numel returns the number of elements in the ComplexTensor.
+func (c *ComplexTensor[T]) Numel() int {
+	return c.layout.Numel()
+}
+
+// Rank returns the ComplexTensor's rank
+// (the number of axes in the ComplexTensor's shape).
+func (c *ComplexTensor[T]) Rank() int {
+	return c.layout.Rank()
+}
+
+// Shape returns a copy of the ComplexTensor's shape.
+func (c *ComplexTensor[T]) Shape() []int {
+	return slice.Copy(c.layout.Shape())
+}
+
+// Size returns the ComplexTensor's total shape size.
+// If axis is specified, the number of dimensions at
+// that axis is returned.
+func (c *ComplexTensor[T]) Size(axis ...int) int {
+	assertArgsBounds(len(axis), 1)
+
+	if len(axis) == 0 {
+		return c.layout.Numel()
+	}
+
+	assertAxisBounds(axis[0], c.Rank())
+	return c.layout.Shape()[axis[0]]
+}
+
+// IsContiguous returns whether or not the ComplexTensor's elements
+// are laid out contiguously in row-major order in its storage.
+func (c *ComplexTensor[T]) IsContiguous() bool {
+	return c.layout.Contiguous()
+}
+
+// Copy copies the ComplexTensor's elements into a new,
+// contiguous ComplexTensor and returns it.
+func (c *ComplexTensor[T]) Copy() *ComplexTensor[T] {
+	return ComplexFromBuffer(c.Ravel(), c.Shape()...)
+}
+
+// Reshape returns a ComplexTensor with the given shape sharing
+// the ComplexTensor's storage, unless it is not contiguous,
+// in which case its elements are copied first.
+func (c *ComplexTensor[T]) Reshape(s ...int) *ComplexTensor[T] {
+	if !c.layout.Contiguous() {
+		c = c.Copy()
+	}
+
+	return c.view(c.layout.Reshape(s))
+}
+
+// Index returns a view over an index of the ComplexTensor.
+func (c *ComplexTensor[T]) Index(indices ...int) *ComplexTensor[T] {
+	return c.view(c.layout.Index(indices))
+}
+
+// Slice returns a view over a slice of the ComplexTensor
+// on the interval [start, end) of its first axis.
+func (c *ComplexTensor[T]) Slice(start, end int) *ComplexTensor[T] {
+	assertGoodShape(c.Shape()...) // make sure ComplexTensor rank is not 0
+
+	return c.SliceAxis(0, start, end, 1)
+}
+
+// SliceAxis returns a view over a slice of the ComplexTensor
+// on the interval [start, end) of the given axis,
+// taking every step-th element.
+func (c *ComplexTensor[T]) SliceAxis(axis, start, end, step int) *ComplexTensor[T] {
+	return c.view(c.layout.Slice(axis, start, end, step))
+}
+
+// Conj returns a ComplexTensor holding the complex
+// conjugates of the ComplexTensor's elements.
+func (c *ComplexTensor[T]) Conj() *ComplexTensor[T] {
+	data := c.Ravel()
+	for i, x := range data {
+		data[i] = T(cmplx.Conj(complex128(x)))
+	}
+
+	return ComplexFromBuffer(data, c.Shape()...)
+}
+
+// Real returns a Tensor holding the real parts
+// of the ComplexTensor's elements.
+func (c *ComplexTensor[T]) Real() *Tensor[float64] {
+	return c.toReal(func(x complex128) float64 { return real(x) })
+}
+
+// Imag returns a Tensor holding the imaginary parts
+// of the ComplexTensor's elements.
+func (c *ComplexTensor[T]) Imag() *Tensor[float64] {
+	return c.toReal(func(x complex128) float64 { return imag(x) })
+}
+
+// Abs returns a Tensor holding the absolute values
+// (or moduli) of the ComplexTensor's elements.
+func (c *ComplexTensor[T]) Abs() *Tensor[float64] {
+	return c.toReal(cmplx.Abs)
+}
+
+// Angle returns a Tensor holding the angles (or phases)
+// of the ComplexTensor's elements, in radians in [-Pi, Pi].
+func (c *ComplexTensor[T]) Angle() *Tensor[float64] {
+	return c.toReal(cmplx.Phase)
+}
+
+// toReal returns a Tensor holding the results of the given
+// function applied to each of the ComplexTensor's elements.
+func (c *ComplexTensor[T]) toReal(f func(complex128) float64) *Tensor[float64] {
+	data := c.flat()
+
+	r := slice.WithLen[float64](len(data))
+	for i, x := range data {
+		r[i] = f(complex128(x))
+	}
+
+	return FromBuffer(r, c.Shape()...)
+}
@@ -0,0 +1,101 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// equalComplex returns whether or not the two ComplexTensors
+// have the same shape and the same elements.
+func equalComplex[T nune.Complex](a, b *ComplexTensor[T]) bool {
+	if !slice.Equal(a.Shape(), b.Shape()) {
+		return false
+	}
+
+	x, y := a.Ravel(), b.Ravel()
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestComplexString(t *testing.T) {
+	data := []complex128{1 + 2i, -3.5 - 0.25i, 0, 10i}
+
+	tests := []struct {
+		name string
+		c    interface{ String() string }
+		want string
+	}{
+		{"scalar", ComplexFromBuffer([]complex128{1 - 1i}), "ComplexTensor(1.0000-1.0000i)"},
+		{"vector", ComplexFromBuffer(data[:2], 2),
+			"ComplexTensor([ 1.0000+2.0000i, -3.5000-0.2500i])"},
+		{"matrix", ComplexFromBuffer([]complex64{1 + 2i, -3.5 - 0.25i, 0, 10i}, 2, 2),
+			"ComplexTensor([[ 1.0000 +2.0000i, -3.5000 -0.2500i]\n" +
+				"               [ 0.0000 +0.0000i,  0.0000+10.0000i]])"},
+		{"view", ComplexFromBuffer(data, 2, 2).SliceAxis(1, 1, 2, 1),
+			"ComplexTensor([[-3.5000 -0.2500i]\n" +
+				"               [ 0.0000+10.0000i]])"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.c.String(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestComplexCasts(t *testing.T) {
+	re := FromBuffer([]float64{1.5, -2, 3.75, 0}, 2, 2)
+	im := FromBuffer([]float64{0.5, 1, -1, 2}, 2, 2)
+
+	c := Complex[complex128](re, im)
+	if want := ComplexFromBuffer([]complex128{1.5 + 0.5i, -2 + 1i, 3.75 - 1i, 2i}, 2, 2); !equalComplex(c, want) {
+		t.Errorf("Complex: got %v, want %v", c, want)
+	}
+
+	if got := CastToComplex[complex64](Range[int](0, 3, 1)); !equalComplex(got, ComplexFromBuffer([]complex64{0, 1, 2}, 3)) {
+		t.Errorf("CastToComplex: got %v", got)
+	}
+
+	if got := CastToReal[int](c); !equal(got, FromBuffer([]int{1, -2, 3, 0}, 2, 2)) {
+		t.Errorf("CastToReal: got %v", got)
+	}
+
+	// complex64 holds these values exactly
+	if got := CastComplex[complex128](CastComplex[complex64](c)); !equalComplex(got, c) {
+		t.Errorf("CastComplex: got %v, want %v", got, c)
+	}
+
+	// casts and parts of strided views follow their elements
+	v := c.SliceAxis(1, 0, 1, 1)
+	if got := CastToReal[float64](v); !equal(got, FromBuffer([]float64{1.5, 3.75}, 2, 1)) {
+		t.Errorf("view: got %v", got)
+	}
+	if got := c.Conj().Imag(); !equal(got, FromBuffer([]float64{-0.5, -1, 1, -2}, 2, 2)) {
+		t.Errorf("Conj: got %v", got)
+	}
+	if got := v.Imag(); !equal(got, FromBuffer([]float64{0.5, -1}, 2, 1)) {
+		t.Errorf("Imag: got %v", got)
+	}
+	if got := ComplexFromBuffer([]complex128{3 + 4i}, 1).Abs(); !equal(got, FromBuffer([]float64{5}, 1)) {
+		t.Errorf("Abs: got %v", got)
+	}
+
+	_, err := try(func() *ComplexTensor[complex128] {
+		return Complex[complex128](re, Ones[float64](4))
+	})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("mismatched parts: got %v, want %v", err, ErrShapeMismatch)
+	}
+}
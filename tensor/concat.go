@@ -0,0 +1,166 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// Concat joins the given Tensors along the given axis, in order,
+// and returns the result in a new Tensor. The Tensors must have
+// the same shape, except along that axis.
+func Concat[T nune.Numeric](axis int, ts ...*Tensor[T]) *Tensor[T] {
+	if len(ts) == 0 {
+		panic(ErrBadShape)
+	}
+	assertAxisBounds(axis, ts[0].Rank())
+
+	shape := ts[0].Shape()
+	for _, t := range ts[1:] {
+		expected := ts[0].Shape()
+		if t.Rank() == len(expected) {
+			expected[axis] = t.Size(axis)
+		}
+
+		if !slice.Equal(t.layout.Shape(), expected) {
+			panic(shapeError("Concat", expected, t.layout.Shape(), ErrShapeMismatch))
+		}
+
+		shape[axis] += t.Size(axis)
+	}
+
+	res := Zeros[T](shape...)
+
+	start := 0
+	for _, t := range ts {
+		end := start + t.Size(axis)
+		dst := res.layout.Slice(axis, start, end, 1)
+		cpd.Copy(t.layout.Shape(), t.span(), res.view(dst).span())
+		start = end
+	}
+
+	return res
+}
+
+// Stack joins the given Tensors along a new axis inserted at the given
+// position, in order, and returns the result in a new Tensor whose
+// dimension at that axis is the number of Tensors. The Tensors must
+// all have the same shape.
+func Stack[T nune.Numeric](axis int, ts ...*Tensor[T]) *Tensor[T] {
+	if len(ts) == 0 {
+		panic(ErrBadShape)
+	}
+	assertAxisBounds(axis, ts[0].Rank()+1)
+
+	for _, t := range ts[1:] {
+		if !slice.Equal(t.layout.Shape(), ts[0].layout.Shape()) {
+			panic(shapeError("Stack", ts[0].layout.Shape(), t.layout.Shape(), ErrShapeMismatch))
+		}
+	}
+
+	shape := append(ts[0].Shape()[:axis], len(ts))
+	shape = append(shape, ts[0].layout.Shape()[axis:]...)
+
+	res := Zeros[T](shape...)
+	for i, t := range ts {
+		cpd.Copy(t.layout.Shape(), t.span(), res.view(res.layout.Select(axis, i)).span())
+	}
+
+	return res
+}
+
+// HStack joins the given Tensors horizontally, that is along
+// their second axis, or along their only axis for rank 1 Tensors.
+func HStack[T nune.Numeric](ts ...*Tensor[T]) *Tensor[T] {
+	if len(ts) != 0 && ts[0].Rank() == 1 {
+		return Concat(0, ts...)
+	}
+
+	return Concat(1, ts...)
+}
+
+// VStack joins the given Tensors vertically, that is along their
+// first axis, rank 1 Tensors being viewed as single rows.
+func VStack[T nune.Numeric](ts ...*Tensor[T]) *Tensor[T] {
+	rows := make([]*Tensor[T], len(ts))
+	for i, t := range ts {
+		if t.Rank() == 1 {
+			t = t.Unsqueeze(0)
+		}
+		rows[i] = t
+	}
+
+	return Concat(0, rows...)
+}
+
+// Split returns views over consecutive slices of the Tensor along the
+// given axis, in order, whose dimensions at that axis are the given
+// sizes. The sizes must be positive and add up to the axis' dimension.
+func (t *Tensor[T]) Split(axis int, sizes ...int) []*Tensor[T] {
+	assertAxisBounds(axis, t.Rank())
+
+	total := 0
+	for _, s := range sizes {
+		if s <= 0 {
+			panic(ErrBadShape)
+		}
+		total += s
+	}
+
+	if total != t.Size(axis) {
+		panic(shapeError("Split", []int{t.Size(axis)}, []int{total}, ErrShapeMismatch))
+	}
+
+	views := make([]*Tensor[T], len(sizes))
+
+	start := 0
+	for i, s := range sizes {
+		views[i] = t.view(t.layout.Slice(axis, start, start+s, 1))
+		start += s
+	}
+
+	return views
+}
+
+// Chunk splits the Tensor into n views along the given axis, as Split
+// does, of equal dimensions at that axis but for the last one, which
+// may be smaller. Fewer than n views are returned if the axis' dimension
+// doesn't allow for n non-empty chunks of equal dimensions.
+func (t *Tensor[T]) Chunk(n, axis int) []*Tensor[T] {
+	assertAxisBounds(axis, t.Rank())
+	if n <= 0 {
+		panic(ErrBadShape)
+	}
+
+	d := t.Size(axis)
+	size := (d + n - 1) / n
+
+	sizes := slice.WithCap[int](n)
+	for d > 0 {
+		if size > d {
+			size = d
+		}
+
+		sizes = append(sizes, size)
+		d -= size
+	}
+
+	return t.Split(axis, sizes...)
+}
+
+// Unbind returns views over each index of the Tensor along
+// the given axis, in order, without that axis.
+func (t *Tensor[T]) Unbind(axis int) []*Tensor[T] {
+	assertAxisBounds(axis, t.Rank())
+
+	views := make([]*Tensor[T], t.Size(axis))
+	for i := range views {
+		views[i] = t.view(t.layout.Select(axis, i))
+	}
+
+	return views
+}
@@ -0,0 +1,187 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	a := Range[int](0, 4, 1).Reshape(2, 2)
+	b := Range[int](4, 8, 1).Reshape(2, 2)
+	c := Range[int](8, 10, 1).Reshape(1, 2)
+
+	tests := []struct {
+		name string
+		got  func() (*Tensor[int], error)
+		want *Tensor[int]
+	}{
+		{"rows", func() (*Tensor[int], error) {
+			return TryConcat(0, a, b, c)
+		}, Range[int](0, 10, 1).Reshape(5, 2)},
+		{"columns", func() (*Tensor[int], error) {
+			return TryConcat(1, a, b)
+		}, FromBuffer([]int{0, 1, 4, 5, 2, 3, 6, 7}, 2, 4)},
+		{"strided", func() (*Tensor[int], error) {
+			return TryConcat(1, a.Transpose(), c.Transpose())
+		}, FromBuffer([]int{0, 2, 8, 1, 3, 9}, 2, 3)},
+		{"single", func() (*Tensor[int], error) {
+			return TryConcat(0, a)
+		}, a},
+		{"stack", func() (*Tensor[int], error) {
+			return TryStack(0, a, b)
+		}, Range[int](0, 8, 1).Reshape(2, 2, 2)},
+		{"stack last", func() (*Tensor[int], error) {
+			return TryStack(2, a, b)
+		}, FromBuffer([]int{0, 4, 1, 5, 2, 6, 3, 7}, 2, 2, 2)},
+		{"hstack", func() (*Tensor[int], error) {
+			return TryHStack(a, b)
+		}, FromBuffer([]int{0, 1, 4, 5, 2, 3, 6, 7}, 2, 4)},
+		{"hstack vectors", func() (*Tensor[int], error) {
+			return TryHStack(Range[int](0, 2, 1), Range[int](2, 5, 1))
+		}, Range[int](0, 5, 1)},
+		{"vstack", func() (*Tensor[int], error) {
+			return TryVStack(a, Range[int](8, 10, 1))
+		}, FromBuffer([]int{0, 1, 2, 3, 8, 9}, 3, 2)},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.got()
+		if err != nil || !equal(got, tt.want) {
+			t.Errorf("%s: got %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	// the result doesn't share the operands' storage
+	res := Concat(0, a, b)
+	res.Index(0).Assign([]int{-1, -1})
+	if !equal(a, Range[int](0, 4, 1).Reshape(2, 2)) {
+		t.Errorf("Concat shares the storage of its operands")
+	}
+}
+
+func TestConcatErrors(t *testing.T) {
+	a := Ones[int](2, 2)
+
+	tests := []struct {
+		name string
+		got  func() (*Tensor[int], error)
+		err  error
+	}{
+		{"none", func() (*Tensor[int], error) {
+			return TryConcat[int](0)
+		}, ErrBadShape},
+		{"axis", func() (*Tensor[int], error) {
+			return TryConcat(2, a, a)
+		}, ErrAxisBounds},
+		{"other axis", func() (*Tensor[int], error) {
+			return TryConcat(0, a, Ones[int](2, 3))
+		}, ErrShapeMismatch},
+		{"rank", func() (*Tensor[int], error) {
+			return TryConcat(0, a, Ones[int](2))
+		}, ErrShapeMismatch},
+		{"stack shapes", func() (*Tensor[int], error) {
+			return TryStack(0, a, Ones[int](1, 2))
+		}, ErrShapeMismatch},
+		{"stack axis", func() (*Tensor[int], error) {
+			return TryStack(3, a, a)
+		}, ErrAxisBounds},
+	}
+
+	for _, tt := range tests {
+		if _, err := tt.got(); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	x := Range[int](0, 10, 1).Reshape(5, 2)
+
+	tests := []struct {
+		name string
+		got  func() ([]*Tensor[int], error)
+		want []*Tensor[int]
+	}{
+		{"split", func() ([]*Tensor[int], error) {
+			return x.TrySplit(0, 1, 4)
+		}, []*Tensor[int]{
+			Range[int](0, 2, 1).Reshape(1, 2),
+			Range[int](2, 10, 1).Reshape(4, 2),
+		}},
+		{"split columns", func() ([]*Tensor[int], error) {
+			return x.TrySplit(1, 1, 1)
+		}, []*Tensor[int]{
+			FromBuffer([]int{0, 2, 4, 6, 8}, 5, 1),
+			FromBuffer([]int{1, 3, 5, 7, 9}, 5, 1),
+		}},
+		{"chunk", func() ([]*Tensor[int], error) {
+			return x.TryChunk(3, 0)
+		}, []*Tensor[int]{
+			Range[int](0, 4, 1).Reshape(2, 2),
+			Range[int](4, 8, 1).Reshape(2, 2),
+			Range[int](8, 10, 1).Reshape(1, 2),
+		}},
+		{"fewer chunks", func() ([]*Tensor[int], error) {
+			return Range[int](0, 6, 1).TryChunk(4, 0)
+		}, []*Tensor[int]{
+			Range[int](0, 2, 1),
+			Range[int](2, 4, 1),
+			Range[int](4, 6, 1),
+		}},
+		{"more chunks than elements", func() ([]*Tensor[int], error) {
+			return x.TryChunk(5, 1)
+		}, []*Tensor[int]{
+			FromBuffer([]int{0, 2, 4, 6, 8}, 5, 1),
+			FromBuffer([]int{1, 3, 5, 7, 9}, 5, 1),
+		}},
+		{"unbind", func() ([]*Tensor[int], error) {
+			return x.TryUnbind(1)
+		}, []*Tensor[int]{
+			FromBuffer([]int{0, 2, 4, 6, 8}, 5),
+			FromBuffer([]int{1, 3, 5, 7, 9}, 5),
+		}},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.got()
+		if err != nil || len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, %v, want %v", tt.name, got, err, tt.want)
+			continue
+		}
+
+		for i := range got {
+			if !equal(got[i], tt.want[i]) {
+				t.Errorf("%s: got %v at %d, want %v", tt.name, got[i], i, tt.want[i])
+			}
+		}
+	}
+
+	// the parts are views over the Tensor
+	x.Unbind(0)[4].Assign([]int{-1, -2})
+	x.Split(1, 1, 1)[1].Index(0).Assign([]int{-3})
+	if want := FromBuffer([]int{0, -3, 2, 3, 4, 5, 6, 7, -1, -2}, 5, 2); !equal(x, want) {
+		t.Errorf("views: got %v, want %v", x, want)
+	}
+
+	bad := []struct {
+		name string
+		got  func() ([]*Tensor[int], error)
+		err  error
+	}{
+		{"sum", func() ([]*Tensor[int], error) { return x.TrySplit(0, 2, 2) }, ErrShapeMismatch},
+		{"size", func() ([]*Tensor[int], error) { return x.TrySplit(0, 5, 0) }, ErrBadShape},
+		{"axis", func() ([]*Tensor[int], error) { return x.TrySplit(2, 5) }, ErrAxisBounds},
+		{"chunks", func() ([]*Tensor[int], error) { return x.TryChunk(0, 0) }, ErrBadShape},
+		{"unbind axis", func() ([]*Tensor[int], error) { return x.TryUnbind(-1) }, ErrAxisBounds},
+	}
+
+	for _, tt := range bad {
+		if _, err := tt.got(); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
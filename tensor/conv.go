@@ -0,0 +1,240 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// A ConvMode selects which part of the convolution
+// of two signals Convolve and Correlate return.
+type ConvMode int
+
+// List of convolution modes, following NumPy's.
+const (
+	// ConvFull returns the convolution at every point where the
+	// signals overlap, of dimension n+m-1 along each axis.
+	ConvFull ConvMode = iota
+
+	// ConvSame returns the convolution centered with respect
+	// to the full one, of the shape of the first signal.
+	ConvSame
+
+	// ConvValid returns the convolution only where the second
+	// signal completely overlaps the first, of dimension n-m+1
+	// along each axis.
+	ConvValid
+)
+
+// Convolve returns the discrete convolution of the two Tensors, which
+// must have the same rank, in the given mode, in a new Tensor, or in out
+// if it is provided. For rank 1 Tensors, the convolution of a and v is
+// (a * v)[n] = Σ a[n-k] v[k], and it extends to higher ranks as the
+// convolution of images or volumes.
+func Convolve[T nune.Numeric](a, v *Tensor[T], mode ConvMode, out ...*Tensor[T]) *Tensor[T] {
+	kernel := v.Ravel()
+	for i, j := 0, len(kernel)-1; i < j; i, j = i+1, j-1 {
+		kernel[i], kernel[j] = kernel[j], kernel[i]
+	}
+
+	return correlate("Convolve", a, v.layout.Shape(), kernel, mode, out)
+}
+
+// Correlate returns the discrete cross-correlation of the two Tensors,
+// which must have the same rank, in the given mode, in a new Tensor, or
+// in out if it is provided. For rank 1 Tensors, the cross-correlation of
+// a and v is (a ⋆ v)[n] = Σ a[n+k] v[k], n being shifted by m-1 in
+// ConvFull mode and by m/2 in ConvSame mode, as in NumPy.
+func Correlate[T nune.Numeric](a, v *Tensor[T], mode ConvMode, out ...*Tensor[T]) *Tensor[T] {
+	return correlate("Correlate", a, v.layout.Shape(), v.flat(), mode, out)
+}
+
+// correlate returns the cross-correlation of the Tensor with
+// the kernel of the given shape, held in row-major order.
+func correlate[T nune.Numeric](op string, a *Tensor[T], kshape []int, kernel []T, mode ConvMode, out []*Tensor[T]) *Tensor[T] {
+	assertGoodShape(a.layout.Shape()...)
+	assertGoodShape(kshape...)
+
+	if a.Rank() != len(kshape) {
+		panic(shapeError(op, a.layout.Shape(), kshape, ErrShapeMismatch))
+	}
+
+	rank := a.Rank()
+	g := cpd.ConvGeometry{
+		In:       a.layout.Shape(),
+		Kernel:   kshape,
+		Out:      slice.WithLen[int](rank),
+		Stride:   repeat(1, rank),
+		Dilation: repeat(1, rank),
+		Pad:      slice.WithLen[int](rank),
+	}
+
+	for i, n := range g.In {
+		m := kshape[i]
+
+		switch mode {
+		case ConvFull:
+			g.Out[i], g.Pad[i] = n+m-1, m-1
+		case ConvSame:
+			g.Out[i], g.Pad[i] = n, m/2
+		case ConvValid:
+			if m > n {
+				panic(shapeError(op, a.layout.Shape(), kshape, ErrShapeMismatch))
+			}
+			g.Out[i] = n - m + 1
+		default:
+			panic(ErrBadConv)
+		}
+	}
+
+	res := result(op, g.Out, out)
+	conv(res, func(dst []T) {
+		cpd.Conv(1, 1, 1, 1, g, a.flat(), kernel, dst)
+	})
+
+	return res
+}
+
+// ConvOptions configures the convolutions computed by Conv1d and Conv2d.
+// Stride, Padding and Dilation hold either a value per spatial axis,
+// or a single value used for all of them. Zero values stand for the
+// defaults: a stride and dilation of 1, no padding and a single group.
+type ConvOptions struct {
+	Stride   []int // the step between the positions of the kernels
+	Padding  []int // the number of zeros added on both sides of each axis
+	Dilation []int // the spacing between the elements of the kernels
+	Groups   int   // the number of groups input and output channels are split into
+}
+
+// Conv1d returns the 1-dimensional convolution, as used in neural
+// networks, of the input x of shape (batch, in channels, length), or
+// (in channels, length), with the kernels w of shape (out channels,
+// in channels / groups, kernel length), in a new Tensor, or in out if it
+// is provided. As in neural networks, the kernels are not flipped,
+// and the result has the shape (batch, out channels, output length),
+// without the batch axis if the input has none.
+func Conv1d[T nune.Numeric](x, w *Tensor[T], opts ConvOptions, out ...*Tensor[T]) *Tensor[T] {
+	return convNd("Conv1d", 1, x, w, opts, out)
+}
+
+// Conv2d returns the 2-dimensional convolution, as used in neural
+// networks, of the input x of shape (batch, in channels, height, width),
+// or (in channels, height, width), with the kernels w of shape (out
+// channels, in channels / groups, kernel height, kernel width), in a new
+// Tensor, or in out if it is provided, as Conv1d does.
+func Conv2d[T nune.Numeric](x, w *Tensor[T], opts ConvOptions, out ...*Tensor[T]) *Tensor[T] {
+	return convNd("Conv2d", 2, x, w, opts, out)
+}
+
+// convNd returns the convolution of x with the kernels w
+// over the given number of spatial axes.
+func convNd[T nune.Numeric](op string, d int, x, w *Tensor[T], opts ConvOptions, out []*Tensor[T]) *Tensor[T] {
+	assertGoodShape(x.layout.Shape()...)
+	assertGoodShape(w.layout.Shape()...)
+
+	batched := x.Rank() == d+2
+	if !batched && x.Rank() != d+1 || w.Rank() != d+2 {
+		panic(ErrBadShape)
+	}
+
+	xs := x.layout.Shape()
+	batch := 1
+	if batched {
+		batch, xs = xs[0], xs[1:]
+	}
+
+	groups := opts.Groups
+	if groups == 0 {
+		groups = 1
+	}
+
+	cin, cout := xs[0], w.Size(0)
+	if groups < 0 || cin%groups != 0 || cout%groups != 0 {
+		panic(ErrBadConv)
+	}
+
+	if w.Size(1) != cin/groups {
+		expected := w.Shape()
+		expected[1] = cin / groups
+
+		panic(shapeError(op, expected, w.layout.Shape(), ErrShapeMismatch))
+	}
+
+	g := cpd.ConvGeometry{
+		In:       xs[1:],
+		Kernel:   w.layout.Shape()[2:],
+		Out:      slice.WithLen[int](d),
+		Stride:   convParam(opts.Stride, d, 1),
+		Dilation: convParam(opts.Dilation, d, 1),
+		Pad:      convParam(opts.Padding, d, 0),
+	}
+
+	for i := range g.Out {
+		if g.Stride[i] <= 0 || g.Dilation[i] <= 0 || g.Pad[i] < 0 {
+			panic(ErrBadConv)
+		}
+
+		span := g.Dilation[i]*(g.Kernel[i]-1) + 1
+		if g.In[i]+2*g.Pad[i] < span {
+			panic(shapeError(op, g.Kernel, g.In, ErrShapeMismatch))
+		}
+
+		g.Out[i] = (g.In[i]+2*g.Pad[i]-span)/g.Stride[i] + 1
+	}
+
+	shape := append([]int{cout}, g.Out...)
+	if batched {
+		shape = append([]int{batch}, shape...)
+	}
+
+	res := result(op, shape, out)
+	conv(res, func(dst []T) {
+		cpd.Conv(batch, groups, cin, cout, g, x.flat(), w.flat(), dst)
+	})
+
+	return res
+}
+
+// convParam returns the values of a convolution parameter for each
+// of the d spatial axes, given either one value per axis, a single
+// value for all of them, or none for the default value.
+func convParam(p []int, d, def int) []int {
+	switch len(p) {
+	case 0:
+		return repeat(def, d)
+	case 1:
+		return repeat(p[0], d)
+	case d:
+		return slice.Copy(p)
+	default:
+		panic(ErrBadConv)
+	}
+}
+
+// conv calls f with a contiguous buffer receiving the Tensor's elements,
+// which is the Tensor's own storage unless the Tensor isn't contiguous.
+func conv[T nune.Numeric](res *Tensor[T], f func(dst []T)) {
+	if res.layout.Contiguous() {
+		f(res.storage.Slice(res.layout.Offset(), res.layout.Offset()+res.Numel()))
+		return
+	}
+
+	dst := slice.WithLen[T](res.Numel())
+	f(dst)
+
+	cpd.Copy(res.layout.Shape(), cpd.Flat(dst, res.layout.Shape()), res.span())
+}
+
+// repeat returns a slice holding n times the value x.
+func repeat(x, n int) []int {
+	s := slice.WithLen[int](n)
+	for i := range s {
+		s[i] = x
+	}
+
+	return s
+}
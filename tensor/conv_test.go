@@ -0,0 +1,196 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+// filled returns a Tensor of the given shape holding small,
+// irregular integers, so that convolutions are computed exactly.
+func filled(seed int, shape ...int) *Tensor[float64] {
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = float64((i*7+seed*13)%11 - 5)
+	}
+
+	return FromBuffer(data, shape...)
+}
+
+// naiveConv2d returns the convolution of the batched input x with the
+// kernels w computed from its definition, one output element at a time.
+func naiveConv2d(x, w *Tensor[float64], stride, pad, dil [2]int, groups int) *Tensor[float64] {
+	xs, ws := x.Shape(), w.Shape()
+	batch, cin, h, wd := xs[0], xs[1], xs[2], xs[3]
+	cout, kh, kw := ws[0], ws[2], ws[3]
+
+	oh := (h+2*pad[0]-dil[0]*(kh-1)-1)/stride[0] + 1
+	ow := (wd+2*pad[1]-dil[1]*(kw-1)-1)/stride[1] + 1
+
+	xd, wdata := x.Ravel(), w.Ravel()
+	res := make([]float64, batch*cout*oh*ow)
+	cg, og := cin/groups, cout/groups
+
+	for b := 0; b < batch; b++ {
+		for oc := 0; oc < cout; oc++ {
+			g := oc / og
+			for oy := 0; oy < oh; oy++ {
+				for ox := 0; ox < ow; ox++ {
+					var sum float64
+					for ic := 0; ic < cg; ic++ {
+						for ky := 0; ky < kh; ky++ {
+							for kx := 0; kx < kw; kx++ {
+								iy := oy*stride[0] - pad[0] + ky*dil[0]
+								ix := ox*stride[1] - pad[1] + kx*dil[1]
+								if iy < 0 || iy >= h || ix < 0 || ix >= wd {
+									continue
+								}
+
+								c := g*cg + ic
+								sum += xd[((b*cin+c)*h+iy)*wd+ix] * wdata[((oc*cg+ic)*kh+ky)*kw+kx]
+							}
+						}
+					}
+					res[((b*cout+oc)*oh+oy)*ow+ox] = sum
+				}
+			}
+		}
+	}
+
+	return FromBuffer(res, batch, cout, oh, ow)
+}
+
+func TestConv2d(t *testing.T) {
+	tests := []struct {
+		name             string
+		x, w             []int
+		stride, pad, dil [2]int
+		groups           int
+	}{
+		{"plain", []int{1, 2, 5, 5}, []int{3, 2, 3, 3}, [2]int{1, 1}, [2]int{0, 0}, [2]int{1, 1}, 1},
+		{"stride and padding", []int{2, 3, 7, 6}, []int{4, 3, 3, 2}, [2]int{2, 2}, [2]int{1, 1}, [2]int{1, 1}, 1},
+		{"dilation", []int{1, 2, 9, 8}, []int{2, 2, 3, 3}, [2]int{1, 2}, [2]int{2, 1}, [2]int{2, 3}, 1},
+		{"groups", []int{2, 4, 6, 5}, []int{6, 2, 2, 3}, [2]int{1, 1}, [2]int{1, 0}, [2]int{1, 1}, 2},
+		{"depthwise", []int{1, 3, 5, 5}, []int{3, 1, 3, 3}, [2]int{2, 1}, [2]int{1, 1}, [2]int{1, 2}, 3},
+		{"im2col", []int{2, 32, 6, 5}, []int{32, 32, 3, 3}, [2]int{1, 2}, [2]int{1, 1}, [2]int{1, 1}, 1},
+		{"im2col groups", []int{1, 128, 5, 5}, []int{64, 64, 2, 2}, [2]int{2, 1}, [2]int{1, 0}, [2]int{2, 1}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, w := filled(1, tt.x...), filled(2, tt.w...)
+			opts := ConvOptions{
+				Stride:   tt.stride[:],
+				Padding:  tt.pad[:],
+				Dilation: tt.dil[:],
+				Groups:   tt.groups,
+			}
+
+			want := naiveConv2d(x, w, tt.stride, tt.pad, tt.dil, tt.groups)
+			if got := Conv2d(x, w, opts); !near(got, want, 1e-9) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+
+			// without a batch axis, into a non-contiguous out
+			out := Zeros[float64](want.Shape()[1:]...).Transpose()
+			Conv2d(x.Index(0), w, opts, out.Transpose())
+			if got := out.Transpose(); !near(got, want.Index(0), 1e-9) {
+				t.Errorf("unbatched: got %v, want %v", got, want.Index(0))
+			}
+		})
+	}
+}
+
+func TestConv1d(t *testing.T) {
+	x, w := filled(3, 2, 4, 11), filled(4, 6, 2, 3)
+	opts := ConvOptions{Stride: []int{2}, Padding: []int{2}, Dilation: []int{2}, Groups: 2}
+
+	want := naiveConv2d(x.Unsqueeze(2), w.Unsqueeze(2), [2]int{1, 2}, [2]int{0, 2}, [2]int{1, 2}, 2).Squeeze(2)
+	if got := Conv1d(x, w, opts); !near(got, want, 1e-9) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestConvErrors(t *testing.T) {
+	x := filled(1, 1, 4, 5, 5)
+
+	tests := []struct {
+		name string
+		w    *Tensor[float64]
+		opts ConvOptions
+		err  error
+	}{
+		{"bad groups", filled(2, 3, 4, 3, 3), ConvOptions{Groups: 3}, ErrBadConv},
+		{"negative padding", filled(2, 2, 4, 3, 3), ConvOptions{Padding: []int{-1}}, ErrBadConv},
+		{"null stride", filled(2, 2, 4, 3, 3), ConvOptions{Stride: []int{1, 0}}, ErrBadConv},
+		{"too many parameters", filled(2, 2, 4, 3, 3), ConvOptions{Dilation: []int{1, 1, 1}}, ErrBadConv},
+		{"channels mismatch", filled(2, 2, 3, 3, 3), ConvOptions{}, ErrShapeMismatch},
+		{"kernel too large", filled(2, 2, 4, 3, 3), ConvOptions{Dilation: []int{3}}, ErrShapeMismatch},
+		{"bad rank", filled(2, 2, 4, 3), ConvOptions{}, ErrBadShape},
+	}
+
+	for _, tt := range tests {
+		if _, err := TryConv2d(x, tt.w, tt.opts); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+// naiveConvolve returns the full convolution of the
+// two rank 1 Tensors computed from its definition.
+func naiveConvolve(a, v []float64) []float64 {
+	res := make([]float64, len(a)+len(v)-1)
+	for n := range res {
+		for k := range v {
+			if n-k >= 0 && n-k < len(a) {
+				res[n] += a[n-k] * v[k]
+			}
+		}
+	}
+
+	return res
+}
+
+func TestConvolve(t *testing.T) {
+	a := filled(5, 9).Ravel()
+
+	for _, m := range []int{1, 2, 3, 4, 9} {
+		v := filled(6, m).Ravel()
+		full := naiveConvolve(a, v)
+		n := len(a)
+
+		tests := []struct {
+			mode ConvMode
+			want []float64
+		}{
+			{ConvFull, full},
+			{ConvSame, full[(m-1)/2 : (m-1)/2+n]},
+			{ConvValid, full[m-1 : n]},
+		}
+
+		for _, tt := range tests {
+			want := FromBuffer(tt.want, len(tt.want))
+			if got := Convolve(FromBuffer(a, n), FromBuffer(v, m), tt.mode); !near(got, want, 1e-9) {
+				t.Errorf("m = %d, mode %d: got %v, want %v", m, tt.mode, got, want)
+			}
+
+			// correlating with the reversed kernel is convolving
+			rev := FromBuffer(v, m).Copy().Reverse()
+			if got := Correlate(FromBuffer(a, n), rev, tt.mode); !near(got, want, 1e-9) {
+				t.Errorf("m = %d, mode %d: correlation: got %v, want %v", m, tt.mode, got, want)
+			}
+		}
+	}
+
+	if _, err := TryConvolve(filled(1, 3), filled(1, 4), ConvValid); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("got %v, want %v", err, ErrShapeMismatch)
+	}
+}
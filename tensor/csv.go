@@ -0,0 +1,190 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lordlarker/nune"
+)
+
+// CSVOptions configures the reading and writing of CSV data.
+// Its zero value describes comma-separated data without a header.
+type CSVOptions[T nune.Numeric] struct {
+	Comma   rune     // the field delimiter, ',' if zero, such as '\t' for TSV
+	Comment rune     // if not zero, lines starting with it are skipped when reading
+	Header  bool     // whether or not the first record is a header, skipped when reading
+	Names   []string // the header written before the records, if not nil
+	Columns []int    // the columns to read, in order, or all of them if nil
+	NA      []string // the fields read as missing values, besides empty ones
+	Fill    T        // the value of missing fields
+}
+
+// ReadCSV reads numeric CSV data from the given reader into a Tensor
+// whose rows are the records, parsing them one at a time without
+// holding the raw data in memory. Data made of a single column
+// is read into a rank 1 Tensor, and into a rank 2 Tensor otherwise.
+func ReadCSV[T nune.Numeric](r io.Reader, opts CSVOptions[T]) (*Tensor[T], error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.Comment = opts.Comment
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+
+	na := make(map[string]bool, len(opts.NA))
+	for _, s := range opts.NA {
+		na[s] = true
+	}
+
+	parse := csvParser[T]()
+
+	var data []T
+	var rows, cols int
+
+	for num := 1; ; num++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadCSV, err)
+		}
+
+		if num == 1 && opts.Header {
+			continue
+		}
+
+		columns := opts.Columns
+		if columns == nil {
+			cols = len(record)
+		} else {
+			cols = len(columns)
+		}
+
+		for j := 0; j < cols; j++ {
+			col := j
+			if columns != nil {
+				col = columns[j]
+			}
+
+			if col < 0 || col >= len(record) {
+				return nil, fmt.Errorf("%w: record %d has no column %d", ErrBadCSV, num, col)
+			}
+
+			field := strings.TrimSpace(record[col])
+			if field == "" || na[field] {
+				data = append(data, opts.Fill)
+				continue
+			}
+
+			x, err := parse(field)
+			if err != nil {
+				return nil, fmt.Errorf("%w: record %d, column %d: %v", ErrBadCSV, num, col, err)
+			}
+			data = append(data, x)
+		}
+
+		rows++
+	}
+
+	if rows == 0 || cols == 0 {
+		return nil, fmt.Errorf("%w: no data", ErrBadCSV)
+	}
+
+	if cols == 1 {
+		return FromBuffer(data, rows), nil
+	}
+
+	return FromBuffer(data, rows, cols), nil
+}
+
+// WriteCSV writes the rank 1 or rank 2 Tensor as CSV data to the given
+// writer, a rank 1 Tensor being written as a single column. Floating-point
+// elements are formatted with nune.FmtConfig.Precision decimals.
+func WriteCSV[T nune.Numeric](w io.Writer, t *Tensor[T], opts CSVOptions[T]) error {
+	if t.Rank() != 1 && t.Rank() != 2 {
+		return ErrBadShape
+	}
+
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+
+	if opts.Names != nil {
+		if err := cw.Write(opts.Names); err != nil {
+			return err
+		}
+	}
+
+	cols := 1
+	if t.Rank() == 2 {
+		cols = t.Size(1)
+	}
+
+	format := csvFormatter[T]()
+	data := t.flat()
+	record := make([]string, cols)
+
+	for i := 0; i < len(data); i += cols {
+		for j := range record {
+			record[j] = format(data[i+j])
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// csvParser returns a function parsing a field into the type T.
+func csvParser[T nune.Numeric]() func(string) (T, error) {
+	switch reflect.TypeOf(T(0)).Kind() {
+	case reflect.Float32, reflect.Float64:
+		return func(s string) (T, error) {
+			x, err := strconv.ParseFloat(s, 64)
+			return T(x), err
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bits := int(reflect.TypeOf(T(0)).Size() * 8)
+		return func(s string) (T, error) {
+			x, err := strconv.ParseUint(s, 10, bits)
+			return T(x), err
+		}
+	default:
+		bits := int(reflect.TypeOf(T(0)).Size() * 8)
+		return func(s string) (T, error) {
+			x, err := strconv.ParseInt(s, 10, bits)
+			return T(x), err
+		}
+	}
+}
+
+// csvFormatter returns a function formatting an element of type T.
+func csvFormatter[T nune.Numeric]() func(T) string {
+	switch reflect.TypeOf(T(0)).Kind() {
+	case reflect.Float32, reflect.Float64:
+		return func(x T) string {
+			return strconv.FormatFloat(float64(x), 'f', nune.FmtConfig.Precision, 64)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(x T) string {
+			return strconv.FormatUint(uint64(x), 10)
+		}
+	default:
+		return func(x T) string {
+			return strconv.FormatInt(int64(x), 10)
+		}
+	}
+}
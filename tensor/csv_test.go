@@ -0,0 +1,81 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	x := Range[int](-4, 8, 1).Reshape(4, 3)
+
+	var b bytes.Buffer
+	opts := CSVOptions[int]{Comma: '\t', Header: true, Names: []string{"a", "b", "c"}}
+	if err := WriteCSV(&b, x, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	y, err := ReadCSV(&b, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equal(y, x) {
+		t.Errorf("got %v, want %v", y, x)
+	}
+
+	if err := WriteCSV(&b, x.Reshape(2, 2, 3), opts); !errors.Is(err, ErrBadShape) {
+		t.Errorf("got %v, want %v", err, ErrBadShape)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts CSVOptions[float64]
+		want *Tensor[float64]
+	}{
+		{"plain", "1,2\n3,4\n", CSVOptions[float64]{}, From[float64]([][]float64{{1, 2}, {3, 4}})},
+		{"single column", "1\n2.5\n", CSVOptions[float64]{}, From[float64]([]float64{1, 2.5})},
+		{"columns", "x,y,z\n1,2,3\n4,5,6\n", CSVOptions[float64]{Header: true, Columns: []int{2, 0}}, From[float64]([][]float64{{3, 1}, {6, 4}})},
+		{"missing values", "1,,NA\n# skipped\n 4 ,5,6\n", CSVOptions[float64]{Comment: '#', NA: []string{"NA"}, Fill: -1}, From[float64]([][]float64{{1, -1, -1}, {4, 5, 6}})},
+	}
+
+	for _, tt := range tests {
+		got, err := ReadCSV(strings.NewReader(tt.data), tt.opts)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadCSVMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts CSVOptions[int8]
+	}{
+		{"empty", "", CSVOptions[int8]{}},
+		{"only a header", "a,b\n", CSVOptions[int8]{Header: true}},
+		{"ragged records", "1,2\n3\n", CSVOptions[int8]{}},
+		{"bad quotes", "1,\"2\n", CSVOptions[int8]{}},
+		{"not a number", "1,x\n", CSVOptions[int8]{}},
+		{"out of range", "1,300\n", CSVOptions[int8]{}},
+		{"missing column", "1,2\n", CSVOptions[int8]{Columns: []int{0, 2}}},
+		{"negative column", "1,2\n", CSVOptions[int8]{Columns: []int{-1}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := ReadCSV(strings.NewReader(tt.data), tt.opts); !errors.Is(err, ErrBadCSV) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, ErrBadCSV)
+		}
+	}
+}
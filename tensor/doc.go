@@ -5,4 +5,16 @@
 // Package tensor implements a numeric n-dimensional
 // generic Tensor, along with a set of functions to
 // create, manipulate and operate on that Tensor.
+//
+// Tensors of complex numbers are held by a ComplexTensor, created with
+// Complex or CastToComplex, whose elements are printed as a+bi.
+// Comparisons between Tensors, such as Gt, return a Mask of booleans,
+// which selects elements through Where, MaskedSelect or MaskedFill.
+// Views over parts of a Tensor are taken with NumPy-like slicing
+// expressions, either parsed by At, as in t.At("1:, ::2, -1, None, ..."),
+// or built from Subscripts for Sl.
+//
+// Functions and methods panic when given invalid arguments. Their Try
+// variants, such as TryFrom or TryAdd, return the error instead, which
+// can be checked against the package's errors with errors.Is.
 package tensor
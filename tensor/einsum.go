@@ -0,0 +1,410 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// ellipsisLabel is the label of the first axis covered by an ellipsis,
+// the following ones being labeled in increasing order.
+const ellipsisLabel = rune(0x10000)
+
+// Einsum evaluates the Einstein summation convention described
+// by the subscripts over the given operands, such as "bij,bjk->bik"
+// for a batched matrix product, and returns the result in a new Tensor.
+//
+// Each operand's axes are labeled by letters. Labels shared between
+// operands are multiplied together, and labels absent from the output
+// are summed over. A label repeated within a single operand selects its
+// diagonal. An ellipsis stands for the leading axes of an operand not
+// covered by labels, which are broadcasted together between operands.
+// If the output is omitted, it is made of the ellipsis axes followed by
+// the labels appearing exactly once, in alphabetical order.
+//
+// With more than two operands, pairs are contracted greedily, picking
+// at each step the pair producing the smallest intermediate result.
+func Einsum[T nune.Numeric](subscripts string, operands ...*Tensor[T]) *Tensor[T] {
+	inputs, output := parseEinsum(subscripts, operands)
+
+	// the sizes of the labels, broadcasting those of dimension 1
+	sizes := make(map[rune]int)
+	for i, labels := range inputs {
+		for j, l := range labels {
+			d := operands[i].Size(j)
+			if s, ok := sizes[l]; !ok || s == 1 {
+				sizes[l] = d
+			} else if d != s && d != 1 {
+				panic(ErrShapeMismatch)
+			}
+		}
+	}
+
+	terms := make([]einsumTerm[T], len(operands))
+	for i, op := range operands {
+		terms[i] = newEinsumTerm(op, inputs[i])
+	}
+
+	// labels that must survive a contraction between the given terms
+	needed := func(i, j int) map[rune]bool {
+		n := make(map[rune]bool)
+		for _, l := range output {
+			n[l] = true
+		}
+		for k, t := range terms {
+			if k != i && k != j {
+				for _, l := range t.labels {
+					n[l] = true
+				}
+			}
+		}
+
+		return n
+	}
+
+	for len(terms) > 1 {
+		bi, bj, cost := 0, 1, -1
+		for i := 0; i < len(terms); i++ {
+			for j := i + 1; j < len(terms); j++ {
+				n := needed(i, j)
+
+				c := 1
+				for l := range union(terms[i].labels, terms[j].labels) {
+					if n[l] {
+						c *= sizes[l]
+					}
+				}
+
+				if cost < 0 || c < cost {
+					bi, bj, cost = i, j, c
+				}
+			}
+		}
+
+		t := contract(terms[bi], terms[bj], needed(bi, bj), sizes)
+
+		terms = append(terms[:bj], terms[bj+1:]...)
+		terms[bi] = t
+	}
+
+	keep := make(map[rune]bool)
+	for _, l := range output {
+		keep[l] = true
+	}
+
+	t := terms[0].sum(keep)
+	perm := slice.WithLen[int](len(output))
+	shape := slice.WithLen[int](len(output))
+	for i, l := range output {
+		perm[i] = t.axis(l)
+		shape[i] = sizes[l]
+	}
+
+	res := t.tensor.Permute(perm...).BroadcastTo(shape...)
+	if !res.IsContiguous() {
+		return res.Copy()
+	}
+
+	for _, op := range operands {
+		if res.storage == op.storage {
+			return res.Copy()
+		}
+	}
+
+	return res
+}
+
+// parseEinsum parses the einsum subscripts into the labels of
+// each operand's axes and the labels of the output's axes.
+func parseEinsum[T nune.Numeric](subscripts string, operands []*Tensor[T]) ([][]rune, []rune) {
+	subscripts = strings.Join(strings.Fields(subscripts), "")
+
+	lhs, rhs, explicit := strings.Cut(subscripts, "->")
+	terms := strings.Split(lhs, ",")
+	if len(terms) != len(operands) {
+		panic(ErrBadSubscripts)
+	}
+
+	var ellipsis int // number of axes covered by the widest ellipsis
+	inputs := make([][]rune, len(operands))
+	counts := make(map[rune]int)
+
+	for i, term := range terms {
+		labels, dots := parseEinsumTerm(term)
+
+		if !dots && len(labels) != operands[i].Rank() ||
+			dots && len(labels)-1 > operands[i].Rank() {
+			panic(ErrBadSubscripts)
+		}
+
+		if dots {
+			n := operands[i].Rank() - (len(labels) - 1)
+			if n > ellipsis {
+				ellipsis = n
+			}
+		}
+
+		inputs[i] = labels
+		for _, l := range labels {
+			counts[l]++
+		}
+	}
+
+	// expand the ellipses, aligning the axes they cover on the right
+	for i, labels := range inputs {
+		expanded := slice.WithCap[rune](operands[i].Rank())
+		for _, l := range labels {
+			if l != ellipsisLabel {
+				expanded = append(expanded, l)
+				continue
+			}
+
+			n := operands[i].Rank() - (len(labels) - 1)
+			for j := ellipsis - n; j < ellipsis; j++ {
+				expanded = append(expanded, ellipsisLabel+rune(j))
+			}
+		}
+		inputs[i] = expanded
+	}
+
+	dims := slice.WithLen[rune](ellipsis)
+	for j := range dims {
+		dims[j] = ellipsisLabel + rune(j)
+	}
+
+	var output []rune
+	if explicit {
+		labels, _ := parseEinsumTerm(rhs)
+
+		seen := make(map[rune]bool)
+		for _, l := range labels {
+			if l == ellipsisLabel {
+				output = append(output, dims...)
+				continue
+			}
+
+			if seen[l] || counts[l] == 0 {
+				panic(ErrBadSubscripts)
+			}
+			seen[l] = true
+
+			output = append(output, l)
+		}
+	} else {
+		output = append(output, dims...)
+
+		var once []rune
+		for l, c := range counts {
+			if c == 1 && l != ellipsisLabel {
+				once = append(once, l)
+			}
+		}
+		sort.Slice(once, func(i, j int) bool {
+			return once[i] < once[j]
+		})
+
+		output = append(output, once...)
+	}
+
+	return inputs, output
+}
+
+// parseEinsumTerm parses the labels of a single einsum term,
+// and reports whether or not it holds an ellipsis,
+// which is labeled as ellipsisLabel.
+func parseEinsumTerm(term string) ([]rune, bool) {
+	var labels []rune
+	var dots bool
+
+	for len(term) > 0 {
+		if strings.HasPrefix(term, "...") {
+			if dots {
+				panic(ErrBadSubscripts)
+			}
+
+			dots = true
+			labels = append(labels, ellipsisLabel)
+			term = term[3:]
+
+			continue
+		}
+
+		c := rune(term[0])
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+			panic(ErrBadSubscripts)
+		}
+
+		labels = append(labels, c)
+		term = term[1:]
+	}
+
+	return labels, dots
+}
+
+// An einsumTerm is an operand of an einsum
+// whose axes are labeled by distinct labels.
+type einsumTerm[T nune.Numeric] struct {
+	tensor *Tensor[T]
+	labels []rune
+}
+
+// newEinsumTerm returns an einsumTerm over the given Tensor, merging
+// the axes sharing the same label into a view over their diagonal.
+func newEinsumTerm[T nune.Numeric](t *Tensor[T], labels []rune) einsumTerm[T] {
+	l := new(layout)
+	l.offset = t.layout.Offset()
+
+	var unique []rune
+	for i, lb := range labels {
+		j := 0
+		for ; j < len(unique); j++ {
+			if unique[j] == lb {
+				break
+			}
+		}
+
+		if j == len(unique) {
+			unique = append(unique, lb)
+			l.shape = append(l.shape, t.Size(i))
+			l.strides = append(l.strides, t.layout.Strides()[i])
+		} else if l.shape[j] != t.Size(i) {
+			panic(ErrShapeMismatch)
+		} else {
+			l.strides[j] += t.layout.Strides()[i]
+		}
+	}
+
+	return einsumTerm[T]{
+		tensor: t.view(l),
+		labels: unique,
+	}
+}
+
+// axis returns the axis of the term labeled by the given label,
+// or -1 if there is none.
+func (e einsumTerm[T]) axis(label rune) int {
+	for i, l := range e.labels {
+		if l == label {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// sum sums the term over its axes whose labels aren't kept.
+func (e einsumTerm[T]) sum(keep map[rune]bool) einsumTerm[T] {
+	var axes []int
+	var labels []rune
+
+	for i, l := range e.labels {
+		if keep[l] {
+			labels = append(labels, l)
+		} else {
+			axes = append(axes, i)
+		}
+	}
+
+	if len(axes) == 0 {
+		return e
+	}
+
+	return einsumTerm[T]{
+		tensor: e.tensor.SumAxis(axes, false),
+		labels: labels,
+	}
+}
+
+// contract contracts the two terms together through a batched matrix
+// product, keeping only the needed labels.
+func contract[T nune.Numeric](a, b einsumTerm[T], needed map[rune]bool, sizes map[rune]int) einsumTerm[T] {
+	keepA, keepB := make(map[rune]bool), make(map[rune]bool)
+	for _, l := range a.labels {
+		keepA[l] = needed[l] || b.axis(l) >= 0
+	}
+	for _, l := range b.labels {
+		keepB[l] = needed[l] || a.axis(l) >= 0
+	}
+
+	a, b = a.sum(keepA), b.sum(keepB)
+
+	var batch, onlyA, onlyB, summed []rune
+	for _, l := range a.labels {
+		switch {
+		case b.axis(l) < 0:
+			onlyA = append(onlyA, l)
+		case needed[l]:
+			batch = append(batch, l)
+		default:
+			summed = append(summed, l)
+		}
+	}
+	for _, l := range b.labels {
+		if a.axis(l) < 0 {
+			onlyB = append(onlyB, l)
+		}
+	}
+
+	// arrange both terms as (batch..., rows, inner) and (batch..., inner, cols)
+	arrange := func(e einsumTerm[T], groups ...[]rune) *Tensor[T] {
+		var perm, shape []int
+		for _, l := range batch {
+			perm = append(perm, e.axis(l))
+			shape = append(shape, e.tensor.Size(e.axis(l)))
+		}
+
+		for _, g := range groups {
+			n := 1
+			for _, l := range g {
+				perm = append(perm, e.axis(l))
+				n *= sizes[l]
+			}
+			shape = append(shape, n)
+		}
+
+		full := slice.WithLen[int](len(perm))
+		for i, p := range perm {
+			full[i] = e.tensor.Size(p)
+			if i >= len(batch) {
+				full[i] = sizes[e.labels[p]]
+			}
+		}
+
+		return e.tensor.Permute(perm...).BroadcastTo(full...).Reshape(shape...)
+	}
+
+	x := MatMul(arrange(a, onlyA, summed), arrange(b, summed, onlyB))
+
+	labels := append(append(append([]rune{}, batch...), onlyA...), onlyB...)
+	shape := slice.WithLen[int](len(labels))
+	for i, l := range labels {
+		if i < len(batch) {
+			shape[i] = x.Size(i)
+		} else {
+			shape[i] = sizes[l]
+		}
+	}
+
+	return einsumTerm[T]{
+		tensor: x.Reshape(shape...),
+		labels: labels,
+	}
+}
+
+// union returns the set of labels held by any of the given lists.
+func union(lists ...[]rune) map[rune]bool {
+	u := make(map[rune]bool)
+	for _, l := range lists {
+		for _, r := range l {
+			u[r] = true
+		}
+	}
+
+	return u
+}
@@ -0,0 +1,92 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEinsum(t *testing.T) {
+	sq := Range[float64](0, 9, 1).Reshape(3, 3)
+	stack := Range[float64](0, 18, 1).Reshape(2, 3, 3)
+
+	a, b, c := filled(1, 2, 3), filled(2, 3, 4), filled(3, 4, 5)
+	u, v := filled(4, 2), filled(5, 5)
+
+	trace := func(x *Tensor[float64]) *Tensor[float64] {
+		var sum float64
+		for i := 0; i < x.Size(0); i++ {
+			sum += x.Index(i, i).Sum()
+		}
+		return From[float64](sum)
+	}
+
+	tests := []struct {
+		name       string
+		subscripts string
+		operands   []*Tensor[float64]
+		want       *Tensor[float64]
+	}{
+		{"trace", "ii", []*Tensor[float64]{sq}, From[float64](12)},
+		{"explicit trace", "ii->", []*Tensor[float64]{sq}, From[float64](12)},
+		{"diagonal", "ii->i", []*Tensor[float64]{sq}, FromBuffer([]float64{0, 4, 8}, 3)},
+		{"batched trace", "...ii->...", []*Tensor[float64]{stack}, FromBuffer([]float64{12, 39}, 2)},
+		{"batched diagonal", "bii->bi", []*Tensor[float64]{stack}, FromBuffer([]float64{0, 4, 8, 9, 13, 17}, 2, 3)},
+		{"transpose", "ij->ji", []*Tensor[float64]{a}, a.Transpose()},
+		{"implicit transpose", "ba", []*Tensor[float64]{a}, a.Transpose()},
+		{"implicit product", "ij,jk", []*Tensor[float64]{a, b}, MatMul(a, b)},
+		{"implicit scalar", "ij,ij", []*Tensor[float64]{a, a}, From[float64](Mul(a, a).Sum())},
+		{"outer", "i,j->ij", []*Tensor[float64]{u, v}, Outer(u, v)},
+		{"sum", "ij->j", []*Tensor[float64]{a}, a.SumAxis([]int{0}, false)},
+		{"ellipsis", "...ij,...jk->...ik", []*Tensor[float64]{
+			filled(6, 2, 1, 2, 3), filled(7, 4, 3, 2),
+		}, MatMul(filled(6, 2, 1, 2, 3), filled(7, 4, 3, 2))},
+		{"implicit ellipsis", "...j,jk", []*Tensor[float64]{
+			filled(6, 2, 4, 3), b,
+		}, MatMul(filled(6, 2, 4, 3), b)},
+		{"chain", "ij,jk,kl->il", []*Tensor[float64]{a, b, c}, MatMul(MatMul(a, b), c)},
+		{"shared label", "ij,ij,ij->i", []*Tensor[float64]{a, filled(8, 2, 3), filled(9, 2, 3)},
+			Mul(Mul(a, filled(8, 2, 3)), filled(9, 2, 3)).SumAxis([]int{1}, false)},
+		{"bilinear form", "i,ij,jk,k->", []*Tensor[float64]{u, a, b, filled(10, 4)},
+			MatMul(MatMul(MatMul(u, a), b), filled(10, 4))},
+		{"trace of a product", "ij,jk,ki", []*Tensor[float64]{a, b, filled(11, 4, 2)},
+			trace(MatMul(MatMul(a, b), filled(11, 4, 2)))},
+	}
+
+	for _, tt := range tests {
+		got, err := TryEinsum(tt.subscripts, tt.operands...)
+		if err != nil || !equal(got, tt.want) {
+			t.Errorf("%s: got %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestEinsumBad(t *testing.T) {
+	a := Ones[float64](2, 3)
+
+	tests := []struct {
+		name       string
+		subscripts string
+		operands   []*Tensor[float64]
+		err        error
+	}{
+		{"operands", "ij,jk->ik", []*Tensor[float64]{a}, ErrBadSubscripts},
+		{"rank", "ijk", []*Tensor[float64]{a}, ErrBadSubscripts},
+		{"ellipsis rank", "...ijk", []*Tensor[float64]{a}, ErrBadSubscripts},
+		{"two ellipses", "...i...", []*Tensor[float64]{a}, ErrBadSubscripts},
+		{"label", "i1", []*Tensor[float64]{a}, ErrBadSubscripts},
+		{"unknown output", "ij->ik", []*Tensor[float64]{a}, ErrBadSubscripts},
+		{"repeated output", "ij->ii", []*Tensor[float64]{a}, ErrBadSubscripts},
+		{"dimensions", "ij,ij->i", []*Tensor[float64]{a, Ones[float64](3, 2)}, ErrShapeMismatch},
+		{"diagonal", "ii", []*Tensor[float64]{a}, ErrShapeMismatch},
+	}
+
+	for _, tt := range tests {
+		if _, err := TryEinsum(tt.subscripts, tt.operands...); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// binaryVersion is the version of the binary encoding of Tensors.
+const binaryVersion = 1
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface,
+// which also makes Tensors encodable with encoding/gob.
+//
+// The encoding starts with a header made of the encoding's version, the
+// Tensor's dtype and byte order as an npy descriptor, and its shape,
+// followed by its elements in row-major order.
+func (t *Tensor[T]) MarshalBinary() ([]byte, error) {
+	descr, width, encode := npyEncoder[T]()
+
+	b := make([]byte, 0, 2+len(descr)+binary.MaxVarintLen64*(t.Rank()+1)+t.Numel()*width)
+	b = append(b, binaryVersion, byte(len(descr)))
+	b = append(b, descr...)
+
+	b = appendUvarint(b, uint64(t.Rank()))
+	for _, d := range t.layout.Shape() {
+		b = appendUvarint(b, uint64(d))
+	}
+
+	data := t.flat()
+
+	n := len(b)
+	b = b[:n+len(data)*width]
+	for i, x := range data {
+		encode(b[n+i*width:], x)
+	}
+
+	return b, nil
+}
+
+// appendUvarint appends the varint encoding of x to b.
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], x)]...)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface,
+// replacing the Tensor by the one encoded by MarshalBinary in data.
+// Elements of other types are converted to T as Cast would.
+func (t *Tensor[T]) UnmarshalBinary(data []byte) error {
+	bad := func(reason string) error {
+		return fmt.Errorf("%w: %s", ErrBadEncoding, reason)
+	}
+
+	if len(data) < 2 {
+		return bad("truncated header")
+	}
+	if data[0] != binaryVersion {
+		return bad(fmt.Sprintf("unsupported version %d", data[0]))
+	}
+
+	n := int(data[1])
+	if len(data) < 2+n {
+		return bad("truncated header")
+	}
+	descr, data := string(data[2:2+n]), data[2+n:]
+
+	width, decode, err := npyDecoder[T](descr)
+	if err != nil {
+		return bad(fmt.Sprintf("unsupported dtype %q", descr))
+	}
+
+	rank, k := binary.Uvarint(data)
+	if k <= 0 || rank > uint64(len(data)) {
+		return bad("truncated header")
+	}
+	data = data[k:]
+
+	shape := slice.WithLen[int](int(rank))
+	numel := 1
+	for i := range shape {
+		// the number of elements is bounded by the size
+		// of the data as it is computed, so it can't overflow
+		d, k := binary.Uvarint(data)
+		if k <= 0 || d == 0 || d > uint64(len(data)/numel) {
+			return bad("bad shape")
+		}
+
+		shape[i] = int(d)
+		numel *= shape[i]
+		data = data[k:]
+
+		if numel > len(data) {
+			return bad("truncated data")
+		}
+	}
+
+	if len(data) != numel*width {
+		return bad("data doesn't match the shape")
+	}
+
+	buf := slice.WithLen[T](numel)
+	for i := range buf {
+		buf[i] = decode(data[i*width:])
+	}
+
+	*t = *FromBuffer(buf, shape...)
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding
+// the Tensor as {"dtype": ..., "shape": [...], "data": [...]},
+// where data holds its elements in row-major order.
+func (t *Tensor[T]) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString(`{"dtype":"`)
+	b.WriteString(reflect.TypeOf(T(0)).Kind().String())
+	b.WriteString(`","shape":[`)
+	for i, d := range t.layout.Shape() {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(d))
+	}
+	b.WriteString(`],"data":[`)
+
+	kind := reflect.TypeOf(T(0)).Kind()
+
+	var buf []byte
+	for i, x := range t.flat() {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		switch kind {
+		case reflect.Float32, reflect.Float64:
+			f := float64(x)
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("%w: %v is not representable in JSON", ErrBadEncoding, f)
+			}
+
+			bits := 64
+			if kind == reflect.Float32 {
+				bits = 32
+			}
+			buf = strconv.AppendFloat(buf[:0], f, 'g', -1, bits)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			buf = strconv.AppendUint(buf[:0], uint64(x), 10)
+		default:
+			buf = strconv.AppendInt(buf[:0], int64(x), 10)
+		}
+
+		b.Write(buf)
+	}
+	b.WriteString("]}")
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, replacing
+// the Tensor by the one encoded in data: either as by MarshalJSON, in
+// which case data may also hold nested arrays, or as a plain number or
+// nested arrays of numbers, which are unwrapped as by From.
+// Elements are converted to T whatever the encoded dtype, and any
+// failure to decode data is reported as ErrBadEncoding.
+func (t *Tensor[T]) UnmarshalJSON(data []byte) error {
+	bad := func(err error) error {
+		return fmt.Errorf("%w: %v", ErrBadEncoding, err)
+	}
+
+	var obj struct {
+		Dtype string          `json:"dtype"`
+		Shape []int           `json:"shape"`
+		Data  json.RawMessage `json:"data"`
+	}
+
+	isObject := bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+	if isObject {
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return bad(err)
+		}
+		if obj.Data == nil {
+			return fmt.Errorf("%w: missing data", ErrBadEncoding)
+		}
+
+		data = obj.Data
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return bad(err)
+	}
+
+	v, err := jsonNumbers[T](v)
+	if err != nil {
+		return err
+	}
+
+	res, err := TryFrom[T](v)
+	if err != nil {
+		return bad(err)
+	}
+
+	if isObject && obj.Shape != nil {
+		if res, err = res.TryReshape(obj.Shape...); err != nil {
+			return bad(err)
+		}
+	}
+
+	*t = *res
+
+	return nil
+}
+
+// jsonNumbers replaces the json.Numbers held by the decoded JSON value
+// by numbers of a single type into which T's values can be converted
+// without loss: int64, uint64 or float64.
+func jsonNumbers[T nune.Numeric](v any) (any, error) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadEncoding, err)
+		}
+
+		switch reflect.TypeOf(T(0)).Kind() {
+		case reflect.Float32, reflect.Float64:
+			return f, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+				return u, nil
+			}
+			return uint64(T(f)), nil
+		default:
+			if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+				return i, nil
+			}
+			return int64(T(f)), nil
+		}
+	case []any:
+		for i, x := range v {
+			n, err := jsonNumbers[T](x)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = n
+		}
+
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected %T", ErrBadEncoding, v)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+// rawBinary returns a binary encoded Tensor made of the given
+// version, descriptor, varints and data.
+func rawBinary(version byte, descr string, varints []uint64, data []byte) []byte {
+	b := append([]byte{version, byte(len(descr))}, descr...)
+	for _, v := range varints {
+		b = appendUvarint(b, v)
+	}
+
+	return append(b, data...)
+}
+
+// blob is encoded with encoding/gob as a Tensor would be.
+type blob []byte
+
+func (b blob) MarshalBinary() ([]byte, error) {
+	return b, nil
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	x := Range[int16](-6, 6, 1).Reshape(3, 4).Transpose()
+
+	b, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// elements are converted to the decoding Tensor's type
+	var y Tensor[float32]
+	if err := y.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if want := Cast[float32](x); !equal(&y, want) {
+		t.Errorf("got %v, want %v", &y, want)
+	}
+
+	var g bytes.Buffer
+	if err := gob.NewEncoder(&g).Encode(x); err != nil {
+		t.Fatal(err)
+	}
+
+	var z Tensor[int16]
+	if err := gob.NewDecoder(&g).Decode(&z); err != nil {
+		t.Fatal(err)
+	}
+	if !equal(&z, x) {
+		t.Errorf("gob: got %v, want %v", &z, x)
+	}
+}
+
+func TestUnmarshalBinaryMalformed(t *testing.T) {
+	data := make([]byte, 6*8)
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{"empty", nil},
+		{"bad version", rawBinary(7, "<f8", []uint64{1, 6}, data)},
+		{"truncated descriptor", []byte{1, 9, '<', 'f'}},
+		{"unknown dtype", rawBinary(1, "<c16", []uint64{1, 3}, data)},
+		{"truncated rank", rawBinary(1, "<f8", nil, nil)},
+		{"excessive rank", rawBinary(1, "<f8", []uint64{1 << 40}, data)},
+		{"truncated dimension", []byte{1, 3, '<', 'f', '8', 1, 0x80}},
+		{"null dimension", rawBinary(1, "<f8", []uint64{2, 3, 0}, data)},
+		{"overflowing shape", rawBinary(1, "<f8", []uint64{2, 1 << 62, 1 << 62}, data)},
+		{"truncated data", rawBinary(1, "<f8", []uint64{2, 2, 3}, data[:40])},
+		{"trailing data", rawBinary(1, "<f8", []uint64{1, 5}, data)},
+	}
+
+	for _, tt := range tests {
+		var x Tensor[float64]
+		if err := x.UnmarshalBinary(tt.raw); !errors.Is(err, ErrBadEncoding) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, ErrBadEncoding)
+		}
+
+		var g bytes.Buffer
+		if err := gob.NewEncoder(&g).Encode(blob(tt.raw)); err != nil {
+			t.Fatal(err)
+		}
+		if err := gob.NewDecoder(&g).Decode(&x); !errors.Is(err, ErrBadEncoding) {
+			t.Errorf("%s: gob: got %v, want %v", tt.name, err, ErrBadEncoding)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	x := From[float64]([][]float64{{1.5, -2}, {0, 1e300}})
+
+	b, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var y Tensor[float64]
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatal(err)
+	}
+	if !equal(&y, x) {
+		t.Errorf("got %v, want %v", &y, x)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want *Tensor[int]
+	}{
+		{"scalar", `7`, From[int](7)},
+		{"nested arrays", `[[1, 2], [3, 4]]`, From[int]([][]int{{1, 2}, {3, 4}})},
+		{"reshaped data", `{"dtype": "float64", "shape": [2, 2], "data": [1, 2, 3, 4.0]}`, From[int]([][]int{{1, 2}, {3, 4}})},
+	}
+
+	for _, tt := range tests {
+		var got Tensor[int]
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !equal(&got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, &got, tt.want)
+		}
+	}
+
+	if _, err := json.Marshal(From[float64]([]float64{1, math.NaN()})); !errors.Is(err, ErrBadEncoding) {
+		t.Errorf("NaN: got %v, want %v", err, ErrBadEncoding)
+	}
+}
+
+func TestUnmarshalJSONMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"bad syntax", `[1, 2`},
+		{"bad object", `{"shape": [2], "data": [1, 2]`},
+		{"missing data", `{"dtype": "int64", "shape": [2]}`},
+		{"string element", `[1, "2"]`},
+		{"object element", `[1, {}]`},
+		{"out of range", `[1, 1e400]`},
+		{"ragged arrays", `[[1, 2], [3]]`},
+		{"empty array", `[]`},
+		{"shape mismatch", `{"shape": [3], "data": [1, 2]}`},
+	}
+
+	for _, tt := range tests {
+		// called directly, as encoding/json rejects
+		// bad syntax before reaching the Tensor
+		var x Tensor[int]
+		if err := x.UnmarshalJSON([]byte(tt.data)); !errors.Is(err, ErrBadEncoding) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, ErrBadEncoding)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// List of errors.
+//
+// Functions and methods panic with these errors, possibly wrapped
+// in a *ShapeError or a *SliceError, while their Try variants and
+// the functions reading serialized Tensors return them, such that
+// they can be checked with errors.Is.
+var (
+	// ErrBadShape occurs when a shape is nil or a has axes whose
+	// dimensions are less than or equal to zero.
+	ErrBadShape = errors.New("nune: received a bad shape")
+
+	// ErrAxisBounds occurs when an axis is out of
+	// (0, tensor rank) bounds.
+	ErrAxisBounds = errors.New("nune: axis out of bounds")
+
+	// ErrIndexBounds occurs when an index is out of
+	// (0, dimension size) bounds.
+	ErrIndexBounds = errors.New("nune: index out of bounds")
+
+	// ErrBadAxes occurs when a list of axes contains duplicates
+	// or does not match the Tensor's rank.
+	ErrBadAxes = errors.New("nune: received bad axes")
+
+	// ErrBroadcast occurs when shapes cannot be broadcasted together.
+	ErrBroadcast = errors.New("nune: shapes could not be broadcasted together")
+
+	// ErrShapeMismatch occurs when the shapes of the operands
+	// of an operation don't agree with each other.
+	ErrShapeMismatch = errors.New("nune: received operands with mismatched shapes")
+
+	// ErrBadSubscripts occurs when einsum subscripts are malformed
+	// or don't match the given operands.
+	ErrBadSubscripts = errors.New("nune: received bad einsum subscripts")
+
+	// ErrBadStep occurs when a step size is null or
+	// is opposite to the inverval's order.
+	ErrBadStep = errors.New("nune: received a bad step size")
+
+	// ErrBadInterval occurs when a null interval, or a descending
+	// interval, or an interval that doesn't fall within the allowed limits
+	// is provided to a function like range or slice.
+	ErrBadInterval = errors.New("nune: received a bad interval")
+
+	// ErrUnwrapBacking occurs when a backing could not be
+	// unwrapped into a 1-dimensional numeric buffer in order
+	// to create a Tensor.
+	ErrUnwrapBacking = errors.New("nune: could not unwrap backing to Tensor")
+
+	// ErrArgsBounds occurs when a function receives more arguments
+	// than it should.
+	ErrArgsBounds = errors.New("nune: received more arguments than allowed")
+
+	// ErrDivisionByZero occurs when a Tensor is divided
+	// by a Tensor holding a zero.
+	ErrDivisionByZero = errors.New("nune: division by zero")
+
+	// ErrBadConv occurs when a convolution receives a bad
+	// mode, stride, padding, dilation or number of groups.
+	ErrBadConv = errors.New("nune: received bad convolution parameters")
+
+	// ErrBadSlice occurs when a slicing expression is malformed,
+	// or holds more subscripts than the Tensor has axes.
+	ErrBadSlice = errors.New("nune: received a bad slicing expression")
+
+	// ErrReadOnly occurs when writing to a Tensor backed
+	// by read-only, externally owned memory.
+	ErrReadOnly = errors.New("nune: write to a read-only Tensor")
+
+	// ErrOverlap occurs when writing to a view whose elements
+	// share storage positions, such as a broadcasted Tensor.
+	ErrOverlap = errors.New("nune: write to a Tensor with overlapping elements")
+
+	// ErrBadNpy occurs when reading npy or npz data which is
+	// malformed or holds an unsupported dtype.
+	ErrBadNpy = errors.New("nune: malformed npy data")
+
+	// ErrBadEncoding occurs when decoding a Tensor
+	// from malformed binary or JSON data.
+	ErrBadEncoding = errors.New("nune: malformed encoded Tensor")
+
+	// ErrBadCSV occurs when reading CSV data which is malformed
+	// or holds fields that aren't numbers.
+	ErrBadCSV = errors.New("nune: malformed CSV data")
+)
+
+// errs lists the errors recovered by the Try variants.
+var errs = []error{
+	ErrBadShape,
+	ErrAxisBounds,
+	ErrIndexBounds,
+	ErrBadAxes,
+	ErrBroadcast,
+	ErrShapeMismatch,
+	ErrBadSubscripts,
+	ErrBadStep,
+	ErrBadInterval,
+	ErrUnwrapBacking,
+	ErrArgsBounds,
+	ErrDivisionByZero,
+	ErrBadConv,
+	ErrBadSlice,
+	ErrReadOnly,
+	ErrOverlap,
+}
+
+// A ShapeError records an operation which failed
+// because of the shape of one of its operands.
+type ShapeError struct {
+	Op       string // the failed operation
+	Expected []int  // the shape the operation expected
+	Got      []int  // the shape the operation received
+	Err      error  // the reason of the failure, one of the errors above
+}
+
+// Error implements the error interface.
+func (e *ShapeError) Error() string {
+	return fmt.Sprintf("%v in %s: expected shape %v, got %v", e.Err, e.Op, e.Expected, e.Got)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *ShapeError) Unwrap() error {
+	return e.Err
+}
+
+// A SliceError records a slicing expression
+// which failed to apply to the shape of a Tensor.
+type SliceError struct {
+	Expr  string // the failed subscript, or the whole expression
+	Axis  int    // the axis the subscript applies to, or -1
+	Shape []int  // the shape of the sliced Tensor
+	Err   error  // the reason of the failure, one of the errors above
+}
+
+// Error implements the error interface.
+func (e *SliceError) Error() string {
+	if e.Axis < 0 {
+		return fmt.Sprintf("%v: %q for shape %v", e.Err, e.Expr, e.Shape)
+	}
+
+	return fmt.Sprintf("%v: %q at axis %d of shape %v", e.Err, e.Expr, e.Axis, e.Shape)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *SliceError) Unwrap() error {
+	return e.Err
+}
+
+// shapeError returns a *ShapeError holding copies of the given shapes.
+func shapeError(op string, expected, got []int, err error) *ShapeError {
+	return &ShapeError{
+		Op:       op,
+		Expected: slice.Copy(expected),
+		Got:      slice.Copy(got),
+		Err:      err,
+	}
+}
+
+// try calls f and returns its result, or the error
+// it panicked with if it is one of the package's errors.
+// Any other panic is propagated.
+func try[R any](f func() R) (res R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if ok && errors.Is(e, cpd.ErrReadOnly) {
+				e = ErrReadOnly // a write which bypassed assertWritable
+			}
+
+			if !ok || !isError(e) {
+				panic(r)
+			}
+
+			err = e
+		}
+	}()
+
+	return f(), nil
+}
+
+// isError returns whether or not err is one of the package's errors.
+func isError(err error) bool {
+	for _, e := range errs {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,130 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+func TestTry(t *testing.T) {
+	x := Range[float64](0, 6, 1).Reshape(2, 3)
+
+	tests := []struct {
+		name string
+		call func() (*Tensor[float64], error)
+		err  error
+	}{
+		{"from", func() (*Tensor[float64], error) {
+			return TryFrom[float64]([][]float64{{1, 2}, {3}})
+		}, ErrUnwrapBacking},
+		{"from buffer", func() (*Tensor[float64], error) {
+			return TryFromBuffer([]float64{1, 2, 3}, 2, 2)
+		}, ErrBadShape},
+		{"reshape", func() (*Tensor[float64], error) {
+			return x.TryReshape(4, 2)
+		}, ErrBadShape},
+		{"index", func() (*Tensor[float64], error) {
+			return x.TryIndex(2)
+		}, ErrIndexBounds},
+		{"slice", func() (*Tensor[float64], error) {
+			return x.TrySlice(1, 0)
+		}, ErrBadInterval},
+		{"permute", func() (*Tensor[float64], error) {
+			return x.TryPermute(0, 0)
+		}, ErrBadAxes},
+		{"move axis", func() (*Tensor[float64], error) {
+			return x.TryMoveAxis(0, 2)
+		}, ErrAxisBounds},
+		{"assign", func() (*Tensor[float64], error) {
+			return x.Copy().TryAssign([]float64{1, 2, 3})
+		}, ErrShapeMismatch},
+		{"div", func() (*Tensor[float64], error) {
+			return TryDiv(x, x)
+		}, ErrDivisionByZero},
+		{"out", func() (*Tensor[float64], error) {
+			return TryAdd(x, x, Zeros[float64](2, 3), Zeros[float64](2, 3))
+		}, ErrArgsBounds},
+		{"success", func() (*Tensor[float64], error) {
+			return x.TryReshape(3, 2)
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		res, err := tt.call()
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+
+		if (err == nil) != (res != nil) {
+			t.Errorf("%s: got result %v along with error %v", tt.name, res, err)
+		}
+	}
+}
+
+func TestShapeError(t *testing.T) {
+	x := Ones[float64](2, 3)
+
+	tests := []struct {
+		name     string
+		call     func() (*Tensor[float64], error)
+		op       string
+		expected []int
+		got      []int
+		err      error
+	}{
+		{"broadcast", func() (*Tensor[float64], error) {
+			return TryAdd(x, Ones[float64](2))
+		}, "BroadcastShapes", []int{2, 3}, []int{2}, ErrBroadcast},
+		{"broadcast to", func() (*Tensor[float64], error) {
+			return x.TryBroadcastTo(4, 3)
+		}, "BroadcastTo", []int{4, 3}, []int{2, 3}, ErrBroadcast},
+		{"reshape", func() (*Tensor[float64], error) {
+			return x.TryReshape(5)
+		}, "Reshape", []int{2, 3}, []int{5}, ErrBadShape},
+		{"matmul", func() (*Tensor[float64], error) {
+			return TryMatMul(x, Ones[float64](2, 4))
+		}, "MatMul", []int{3, 4}, []int{2, 4}, ErrShapeMismatch},
+		{"out", func() (*Tensor[float64], error) {
+			return TrySub(x, x, Zeros[float64](3, 2))
+		}, "Sub", []int{2, 3}, []int{3, 2}, ErrBadShape},
+		{"from buffer", func() (*Tensor[float64], error) {
+			return TryFromBuffer(make([]float64, 5), 2, 3)
+		}, "FromBuffer", []int{2, 3}, []int{5}, ErrBadShape},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.call()
+
+		var se *ShapeError
+		if !errors.As(err, &se) {
+			t.Errorf("%s: got %v, want a *ShapeError", tt.name, err)
+			continue
+		}
+
+		if se.Op != tt.op || !slice.Equal(se.Expected, tt.expected) || !slice.Equal(se.Got, tt.got) {
+			t.Errorf("%s: got %q, %v, %v, want %q, %v, %v",
+				tt.name, se.Op, se.Expected, se.Got, tt.op, tt.expected, tt.got)
+		}
+
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestTryPropagates(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("got %v, want the original panic", r)
+		}
+	}()
+
+	try(func() *Tensor[float64] { panic("boom") })
+
+	t.Error("try recovered a foreign panic")
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
 	"github.com/lordlarker/nune/internal/slice"
 )
 
@@ -30,6 +31,10 @@ func Seed(seed int64) {
 // TODO: optimize the hell out of this function and
 // its helper functions.
 func From[T nune.Numeric](b any) *Tensor[T] {
+	if b == nil {
+		panic(ErrUnwrapBacking)
+	}
+
 	switch k := reflect.TypeOf(b).Kind(); k {
 	case reflect.String:
 		b = any([]byte(b.(string)))
@@ -57,11 +62,40 @@ func From[T nune.Numeric](b any) *Tensor[T] {
 		} else if c, ok := anyToTensor[T](b); ok {
 			return c
 		} else {
-			panic(errUnwrapBacking)
+			panic(ErrUnwrapBacking)
 		}
 	}
 }
 
+// FromBuffer returns a Tensor of the given shape backed by
+// the given buffer, without copying it.
+// The buffer holds the Tensor's elements in row-major order.
+func FromBuffer[T nune.Numeric](data []T, shape ...int) *Tensor[T] {
+	if len(shape) != 0 {
+		assertGoodShape(shape...)
+	}
+
+	if len(data) != slice.Prod(shape) {
+		panic(shapeError("FromBuffer", shape, []int{len(data)}, ErrBadShape))
+	}
+
+	return &Tensor[T]{
+		storage: newStorage(data),
+		layout:  newLayout(slice.Copy(shape)),
+	}
+}
+
+// FromExternal returns a read-only Tensor of the given shape backed by
+// externally owned memory, such as a memory-mapped file, without copying
+// it. Writing to the Tensor, or to any view over it, panics with
+// ErrReadOnly, while its Copy is a regular, writable Tensor.
+func FromExternal[T nune.Numeric](data []T, shape ...int) *Tensor[T] {
+	t := FromBuffer(data, shape...)
+	t.storage = cpd.NewReadOnlyStorage(data)
+
+	return t
+}
+
 // Full returns a Tensor filled with the given value and
 // satisfying the given shape.
 func Full[T nune.Numeric](x T, shape []int) *Tensor[T] {
@@ -11,31 +11,53 @@ import (
 	"strings"
 
 	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
 )
 
 // String returns a string representation of the Tensor.
 func (t *Tensor[T]) String() string {
 	template := "Tensor({})"
-	f := newFmtState(template, t)
+	f := newFmtState(template, t.Rank(), fmtReal(t))
 
-	s := strings.Replace(template, "{}", fmtTensor(t, f), 1)
+	return strings.Replace(template, "{}", fmtTensor(t.storage, t.layout, f), 1)
+}
+
+// String returns a string representation of the ComplexTensor,
+// whose elements are formatted as a+bi.
+func (c *ComplexTensor[T]) String() string {
+	template := "ComplexTensor({})"
+	f := newFmtState(template, c.Rank(), fmtComplex(c))
+
+	return strings.Replace(template, "{}", fmtTensor(c.storage, c.layout, f), 1)
+}
+
+// String returns a string representation of the Mask.
+func (m *Mask) String() string {
+	template := "Mask({})"
+	f := newFmtState(template, m.Rank(), func(x uint8) string {
+		if x != 0 {
+			return " true"
+		}
+		return "false"
+	})
 
-	return fmt.Sprintf("%s", s)
+	return strings.Replace(template, "{}", fmtTensor(m.storage, m.layout, f), 1)
 }
 
-// fmtTensor formats the Tensor into a string.
-func fmtTensor[T nune.Numeric](t *Tensor[T], s fmtState) string {
+// fmtTensor formats the elements of the given storage
+// viewed through the given layout into a string.
+func fmtTensor[T nune.Number](st *cpd.Storage[T], l *layout, s fmtState[T]) string {
 	var b strings.Builder
 
-	if t.Rank() == 0 {
-		b.WriteString(fmtNum(t.storage.Index(0), s))
+	if l.Rank() == 0 {
+		b.WriteString(s.num(st.Index(l.Offset())))
 	} else {
 		b.WriteString("[")
 
-		if t.Size(0) > nune.FmtConfig.Excerpt {
-			b.WriteString(fmtExcerpted(t, s))
+		if l.Shape()[0] > nune.FmtConfig.Excerpt {
+			b.WriteString(fmtExcerpted(st, l, s))
 		} else {
-			b.WriteString(fmtComplete(t, s))
+			b.WriteString(fmtComplete(st, l, s))
 		}
 
 		b.WriteString("]")
@@ -44,33 +66,67 @@ func fmtTensor[T nune.Numeric](t *Tensor[T], s fmtState) string {
 	return b.String()
 }
 
+// fmtReal returns a function formatting the elements
+// of the given Tensor with a common width.
+func fmtReal[T nune.Numeric](t *Tensor[T]) func(T) string {
+	width := cfgWidth(t)
+
+	return func(x T) string {
+		return fmtNum(x, width)
+	}
+}
+
 // fmtNum formats a numeric type into a string.
-func fmtNum[T nune.Numeric](x T, s fmtState) string {
+func fmtNum[T nune.Numeric](x T, width int) string {
 	switch reflect.ValueOf(x).Kind() {
 	case reflect.Float32, reflect.Float64:
-		return fmt.Sprintf("%*.*f", s.width, nune.FmtConfig.Precision, float64(x))
+		return fmt.Sprintf("%*.*f", width, nune.FmtConfig.Precision, float64(x))
 	case reflect.Uint8:
 		if nune.FmtConfig.Btoa {
 			return fmt.Sprintf("%s", string(byte(x)))
 		}
 		fallthrough
 	default:
-		return fmt.Sprintf("%*d", s.width, int64(x))
+		return fmt.Sprintf("%*d", width, int64(x))
+	}
+}
+
+// fmtComplex returns a function formatting the elements of the given
+// ComplexTensor as a+bi, their real and imaginary parts being aligned
+// on a common width.
+func fmtComplex[T nune.Complex](c *ComplexTensor[T]) func(T) string {
+	p := nune.FmtConfig.Precision
+
+	var wr, wi int
+	for _, x := range c.flat() {
+		re, im := real(complex128(x)), imag(complex128(x))
+
+		if l := len(fmt.Sprintf("%.*f", p, re)); l > wr {
+			wr = l
+		}
+		if l := len(fmt.Sprintf("%+.*f", p, im)); l > wi {
+			wi = l
+		}
+	}
+
+	return func(x T) string {
+		re, im := real(complex128(x)), imag(complex128(x))
+		return fmt.Sprintf("%*.*f%*s", wr, p, re, wi+1, fmt.Sprintf("%+.*fi", p, im))
 	}
 }
 
 // fmtExcerpted formats an excerpted representation of
-// a Tensor into a string.
-func fmtExcerpted[T nune.Numeric](t *Tensor[T], s fmtState) string {
+// the elements viewed through the given layout into a string.
+func fmtExcerpted[T nune.Number](st *cpd.Storage[T], l *layout, s fmtState[T]) string {
 	var b strings.Builder
 
 	var f string
 
-	f = fmtTensor(t.Slice(0, nune.FmtConfig.Excerpt/2), s)
+	f = fmtTensor(st, l.Slice(0, 0, nune.FmtConfig.Excerpt/2, 1), s)
 	f = f[1 : len(f)-1]
 	b.WriteString(f)
 
-	if t.Rank() == 1 {
+	if l.Rank() == 1 {
 		b.WriteString(", ..., ")
 	} else {
 		b.WriteString("\n")
@@ -79,7 +135,8 @@ func fmtExcerpted[T nune.Numeric](t *Tensor[T], s fmtState) string {
 		b.WriteString(strings.Repeat(" ", s.pad+1))
 	}
 
-	f = fmtTensor(t.Slice(t.Size(0)-nune.FmtConfig.Excerpt/2, t.Size(0)), s)
+	size := l.Shape()[0]
+	f = fmtTensor(st, l.Slice(0, size-nune.FmtConfig.Excerpt/2, size, 1), s)
 	f = f[1 : len(f)-1]
 	b.WriteString(f)
 
@@ -87,21 +144,22 @@ func fmtExcerpted[T nune.Numeric](t *Tensor[T], s fmtState) string {
 }
 
 // fmtComplete formats a complete representation of
-// a Tensor into a string.
-func fmtComplete[T nune.Numeric](t *Tensor[T], s fmtState) string {
+// the elements viewed through the given layout into a string.
+func fmtComplete[T nune.Number](st *cpd.Storage[T], l *layout, s fmtState[T]) string {
 	var b strings.Builder
 
-	for i := 0; i < t.Size(0); i++ {
-		if t.Rank() == 1 {
-			b.WriteString(fmtTensor(t.Index(i), s))
+	size := l.Shape()[0]
+	for i := 0; i < size; i++ {
+		if l.Rank() == 1 {
+			b.WriteString(fmtTensor(st, l.Index([]int{i}), s))
 
-			if i < t.Size(0)-1 {
+			if i < size-1 {
 				b.WriteString(", ")
 			}
 		} else {
-			b.WriteString(fmtTensor(t.Index(i), s.update()))
+			b.WriteString(fmtTensor(st, l.Index([]int{i}), s.update()))
 
-			if i < t.Size(0)-1 {
+			if i < size-1 {
 				b.WriteString(strings.Repeat("\n", s.esc))
 				b.WriteString(strings.Repeat(" ", s.pad+1))
 			}
@@ -112,12 +170,13 @@ func fmtComplete[T nune.Numeric](t *Tensor[T], s fmtState) string {
 }
 
 // A fmtState holds the format configurations while formatting a Tensor.
-type fmtState struct {
-	depth, esc, pad, width int
+type fmtState[T nune.Number] struct {
+	depth, esc, pad int
+	num             func(T) string // formats a single element
 }
 
 // update prepares all the fmtState configurations for the next format call.
-func (f fmtState) update() fmtState {
+func (f fmtState[T]) update() fmtState[T] {
 	f.depth += 1
 	f.esc -= 1
 	f.pad += 1
@@ -125,16 +184,17 @@ func (f fmtState) update() fmtState {
 	return f
 }
 
-// newFmtState returns a new fmtState configured to
-// a base Tensor representation.
-func newFmtState[T nune.Numeric](fmt string, t *Tensor[T]) fmtState {
-	s := fmtState{
+// newFmtState returns a new fmtState configured to a base
+// representation of a Tensor of the given rank, whose
+// elements are formatted by the given function.
+func newFmtState[T nune.Number](fmt string, rank int, num func(T) string) fmtState[T] {
+	s := fmtState[T]{
 		depth: 0,
-		esc:   t.Rank() - 1,
+		esc:   rank - 1,
+		num:   num,
 	}
 
 	s.pad = cfgPad(fmt)
-	s.width = cfgWidth(t)
 
 	return s
 }
@@ -0,0 +1,150 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// Take returns the elements of the Tensor, viewed as a rank 1 Tensor
+// in row-major order, at the given indices, in a new Tensor of
+// the indices' shape.
+func (t *Tensor[T]) Take(indices *Tensor[int]) *Tensor[T] {
+	data := t.flat()
+	assertIndices(indices, len(data))
+
+	// taking is gathering along a unit axis, each index being
+	// on its own line, from the data broadcasted to every line
+	n := indices.Numel()
+	idx := cpd.Flat(indices.flat(), []int{n, 1})
+	src := cpd.Span[T]{Buf: data, Strides: []int{0, 1}}
+
+	res := slice.WithLen[T](n)
+	cpd.Gather([]int{n, 1}, 1, idx, src, cpd.Flat(res, []int{n, 1}))
+
+	return FromBuffer(res, indices.Shape()...)
+}
+
+// IndexSelect returns the slices of the Tensor at the given indices of
+// the given axis, in order, in a new Tensor whose dimension at that axis
+// is the number of indices. The indices are held by a rank 1 Tensor.
+func (t *Tensor[T]) IndexSelect(axis int, indices *Tensor[int]) *Tensor[T] {
+	assertAxisBounds(axis, t.Rank())
+	if indices.Rank() != 1 {
+		panic(shapeError("IndexSelect", []int{indices.Numel()}, indices.layout.Shape(), ErrBadShape))
+	}
+	assertIndices(indices, t.Size(axis))
+
+	shape := t.Shape()
+	shape[axis] = indices.Size(0)
+
+	// the indices are broadcasted along every other axis
+	strides := slice.WithLen[int](len(shape))
+	strides[axis] = indices.layout.Strides()[0]
+	idx := cpd.Span[int]{
+		Buf:     indices.storage.Load(),
+		Strides: strides,
+		Offset:  indices.layout.Offset(),
+	}
+
+	res := Zeros[T](shape...)
+	cpd.Gather(shape, axis, idx, t.span(), res.span())
+
+	return res
+}
+
+// Gather returns the elements of the Tensor at the positions of the
+// indices Tensor, except along the given axis, where they are at the
+// positions held by the indices, in a new Tensor of the indices' shape.
+// For a rank 3 Tensor and the axis 1, it holds
+//
+//	res[i][j][k] = t[i][indices[i][j][k]][k]
+//
+// The indices Tensor must have the Tensor's rank, and dimensions
+// no greater than the Tensor's along the other axes.
+func (t *Tensor[T]) Gather(axis int, indices *Tensor[int]) *Tensor[T] {
+	assertAxisBounds(axis, t.Rank())
+	assertIndexShape("Gather", axis, indices, t.layout.Shape(), false)
+	assertIndices(indices, t.Size(axis))
+
+	res := Zeros[T](indices.layout.Shape()...)
+	cpd.Gather(indices.layout.Shape(), axis, indices.span(), t.span(), res.span())
+
+	return res
+}
+
+// Scatter returns a copy of the Tensor into which the elements of src
+// at the positions of the indices Tensor are written at the same
+// positions, except along the given axis, where they are written at the
+// positions held by the indices. For a rank 3 Tensor and the axis 1,
+// it sets
+//
+//	res[i][indices[i][j][k]][k] = src[i][j][k]
+//
+// When several elements are written to the same position, the last
+// one along the axis is kept. The indices Tensor must have the rank of
+// the Tensor and src, and dimensions no greater than src's, and than
+// the Tensor's along the other axes.
+func (t *Tensor[T]) Scatter(axis int, indices *Tensor[int], src *Tensor[T]) *Tensor[T] {
+	return t.Copy().ScatterInPlace(axis, indices, src)
+}
+
+// ScatterInPlace is like Scatter, but writes into the Tensor,
+// by reference, and then returns it.
+func (t *Tensor[T]) ScatterInPlace(axis int, indices *Tensor[int], src *Tensor[T]) *Tensor[T] {
+	return t.scatter("Scatter", axis, indices, src, false)
+}
+
+// ScatterAdd is like Scatter, but adds the elements of src to those of
+// the copy of the Tensor instead, elements written to the same position
+// being all added up.
+func (t *Tensor[T]) ScatterAdd(axis int, indices *Tensor[int], src *Tensor[T]) *Tensor[T] {
+	return t.Copy().ScatterAddInPlace(axis, indices, src)
+}
+
+// ScatterAddInPlace is like ScatterAdd, but adds to the Tensor's
+// elements, by reference, and then returns the Tensor.
+func (t *Tensor[T]) ScatterAddInPlace(axis int, indices *Tensor[int], src *Tensor[T]) *Tensor[T] {
+	return t.scatter("ScatterAdd", axis, indices, src, true)
+}
+
+// scatter writes or adds the elements of src into the Tensor
+// at the positions held by the indices Tensor.
+func (t *Tensor[T]) scatter(op string, axis int, indices *Tensor[int], src *Tensor[T], add bool) *Tensor[T] {
+	assertWritable(t)
+	assertAxisBounds(axis, t.Rank())
+	assertIndexShape(op, axis, indices, src.layout.Shape(), true)
+	assertIndexShape(op, axis, indices, t.layout.Shape(), false)
+	assertIndices(indices, t.Size(axis))
+
+	cpd.Scatter(indices.layout.Shape(), axis, indices.span(), src.span(), t.span(), add)
+
+	return t
+}
+
+// assertIndexShape makes sure the indices Tensor has the rank of
+// the given shape, and dimensions no greater than the shape's, along
+// every axis if all is true, or along the axes other than the given
+// one otherwise.
+func assertIndexShape(op string, axis int, indices *Tensor[int], shape []int, all bool) {
+	if indices.Rank() != len(shape) {
+		panic(shapeError(op, shape, indices.layout.Shape(), ErrShapeMismatch))
+	}
+
+	for d, n := range indices.layout.Shape() {
+		if (all || d != axis) && n > shape[d] {
+			panic(shapeError(op, shape, indices.layout.Shape(), ErrShapeMismatch))
+		}
+	}
+}
+
+// assertIndices makes sure the indices are in the interval [0, size),
+// and panics otherwise.
+func assertIndices(indices *Tensor[int], size int) {
+	for _, i := range indices.flat() {
+		assertInRange(i, 0, size)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTake(t *testing.T) {
+	x := Range[int](0, 6, 1).Reshape(2, 3)
+
+	tests := []struct {
+		name    string
+		t       *Tensor[int]
+		indices *Tensor[int]
+		want    *Tensor[int]
+	}{
+		{"flat", x, FromBuffer([]int{5, 0, 3}, 3), FromBuffer([]int{5, 0, 3}, 3)},
+		{"shaped", x, FromBuffer([]int{1, 2, 4, 5}, 2, 2), FromBuffer([]int{1, 2, 4, 5}, 2, 2)},
+		{"duplicates", x, FromBuffer([]int{2, 2, 2}, 3), FromBuffer([]int{2, 2, 2}, 3)},
+		{"transposed", x.Transpose(), FromBuffer([]int{0, 1, 2, 3}, 4), FromBuffer([]int{0, 3, 1, 4}, 4)},
+		{"strided indices", x, FromBuffer([]int{0, 1, 2, 3}, 2, 2).Transpose(), FromBuffer([]int{0, 2, 1, 3}, 2, 2)},
+	}
+
+	for _, tt := range tests {
+		if got := tt.t.Take(tt.indices); !equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	for _, i := range []int{-1, 6} {
+		if _, err := x.TryTake(FromBuffer([]int{0, i}, 2)); !errors.Is(err, ErrIndexBounds) {
+			t.Errorf("index %d: got %v, want %v", i, err, ErrIndexBounds)
+		}
+	}
+}
+
+func TestIndexSelect(t *testing.T) {
+	x := Range[int](0, 6, 1).Reshape(2, 3)
+
+	if got, want := x.IndexSelect(1, FromBuffer([]int{2, 0, 2}, 3)), FromBuffer([]int{2, 0, 2, 5, 3, 5}, 2, 3); !equal(got, want) {
+		t.Errorf("columns: got %v, want %v", got, want)
+	}
+	if got, want := x.IndexSelect(0, FromBuffer([]int{1}, 1)), FromBuffer([]int{3, 4, 5}, 1, 3); !equal(got, want) {
+		t.Errorf("rows: got %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		axis    int
+		indices *Tensor[int]
+		err     error
+	}{
+		{"out of range", 0, FromBuffer([]int{2}, 1), ErrIndexBounds},
+		{"rank", 1, Zeros[int](1, 1), ErrBadShape},
+		{"axis", 2, Zeros[int](1), ErrAxisBounds},
+	}
+
+	for _, tt := range tests {
+		if _, err := x.TryIndexSelect(tt.axis, tt.indices); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestGather(t *testing.T) {
+	x := Range[int](0, 6, 1).Reshape(2, 3)
+
+	tests := []struct {
+		name    string
+		axis    int
+		indices *Tensor[int]
+		want    *Tensor[int]
+	}{
+		{"rows", 0, FromBuffer([]int{1, 0, 1}, 1, 3), FromBuffer([]int{3, 1, 5}, 1, 3)},
+		{"columns", 1, FromBuffer([]int{2, 2, 0, 1}, 2, 2), FromBuffer([]int{2, 2, 3, 4}, 2, 2)},
+		{"more indices", 1, FromBuffer([]int{0, 1, 2, 1, 0}, 1, 5), FromBuffer([]int{0, 1, 2, 1, 0}, 1, 5)},
+	}
+
+	for _, tt := range tests {
+		if got := x.Gather(tt.axis, tt.indices); !equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	bad := []struct {
+		name    string
+		axis    int
+		indices *Tensor[int]
+		err     error
+	}{
+		{"out of range", 1, FromBuffer([]int{3}, 1, 1), ErrIndexBounds},
+		{"negative", 0, FromBuffer([]int{-1}, 1, 1), ErrIndexBounds},
+		{"rank", 0, Zeros[int](3), ErrShapeMismatch},
+		{"other axis", 1, Zeros[int](3, 1), ErrShapeMismatch},
+	}
+
+	for _, tt := range bad {
+		if _, err := x.TryGather(tt.axis, tt.indices); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestScatter(t *testing.T) {
+	x := Zeros[int](2, 3)
+	src := FromBuffer([]int{1, 2, 3, 4, 5, 6}, 2, 3)
+
+	tests := []struct {
+		name    string
+		axis    int
+		indices *Tensor[int]
+		want    *Tensor[int]
+		wantAdd *Tensor[int]
+	}{
+		{"rows", 0, FromBuffer([]int{1, 0, 1}, 1, 3),
+			FromBuffer([]int{0, 2, 0, 1, 0, 3}, 2, 3),
+			FromBuffer([]int{0, 2, 0, 1, 0, 3}, 2, 3)},
+		{"columns", 1, FromBuffer([]int{2, 0, 1, 0}, 2, 2),
+			FromBuffer([]int{2, 0, 1, 5, 4, 0}, 2, 3),
+			FromBuffer([]int{2, 0, 1, 5, 4, 0}, 2, 3)},
+		// the last element written to a position is kept by Scatter,
+		// while all of them are added up by ScatterAdd
+		{"duplicates", 1, FromBuffer([]int{0, 0, 0, 2, 2, 2}, 2, 3),
+			FromBuffer([]int{3, 0, 0, 0, 0, 6}, 2, 3),
+			FromBuffer([]int{6, 0, 0, 0, 0, 15}, 2, 3)},
+	}
+
+	for _, tt := range tests {
+		if got := x.Scatter(tt.axis, tt.indices, src); !equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		if got := x.ScatterAdd(tt.axis, tt.indices, src); !equal(got, tt.wantAdd) {
+			t.Errorf("%s: add: got %v, want %v", tt.name, got, tt.wantAdd)
+		}
+	}
+
+	if !equal(x, Zeros[int](2, 3)) {
+		t.Errorf("Scatter modified the Tensor to %v", x)
+	}
+
+	y := Ones[int](2, 3)
+	if got := y.ScatterAddInPlace(0, FromBuffer([]int{1, 1}, 1, 2), src); got != y || !equal(y, FromBuffer([]int{1, 1, 1, 2, 3, 1}, 2, 3)) {
+		t.Errorf("in place: got %v", y)
+	}
+
+	bad := []struct {
+		name    string
+		indices *Tensor[int]
+		src     *Tensor[int]
+		err     error
+	}{
+		{"out of range", FromBuffer([]int{3}, 1, 1), src, ErrIndexBounds},
+		{"negative", FromBuffer([]int{-1}, 1, 1), src, ErrIndexBounds},
+		{"larger than src", Zeros[int](2, 3), Ones[int](2, 2), ErrShapeMismatch},
+		{"rank", Zeros[int](2), src, ErrShapeMismatch},
+	}
+
+	for _, tt := range bad {
+		if _, err := x.TryScatter(1, tt.indices, tt.src); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+		if _, err := x.TryScatterAdd(1, tt.indices, tt.src); !errors.Is(err, tt.err) {
+			t.Errorf("%s: add: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -4,12 +4,15 @@
 
 package tensor
 
-import "github.com/lordlarker/nune/internal/slice"
+import (
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
 
 type layout struct {
 	shape   []int
 	strides []int
-	offset int
+	offset  int
 }
 
 func newLayout(shape []int) *layout {
@@ -17,14 +20,7 @@ func newLayout(shape []int) *layout {
 	l.shape = shape
 
 	if len(shape) != 0 {
-		strides := slice.WithLen[int](len(shape))
-		strides[0] = slice.Prod(shape[1:])
-
-		for i := 1; i < len(shape); i++ {
-			strides[i] = strides[i-1] / shape[i]
-		}
-
-		l.strides = strides
+		l.strides = cpd.Strides(shape)
 	}
 
 	return l
@@ -58,10 +54,344 @@ func (l *layout) SetOffset(offset int) {
 	l.offset = offset
 }
 
+func (l *layout) Numel() int {
+	return slice.Prod(l.shape)
+}
+
+func (l *layout) Contiguous() bool {
+	return cpd.Contiguous(l.shape, l.strides)
+}
+
+// Overlapping returns whether or not several of the layout's
+// elements share the same position, through a null stride
+// along an axis whose dimension is greater than 1.
+func (l *layout) Overlapping() bool {
+	for i, s := range l.strides {
+		if s == 0 && l.shape[i] > 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Broadcast returns a layout viewing the same elements with the
+// given shape, repeating the axes of dimension 1 through null strides.
+// It panics if the layout cannot be broadcasted to the given shape.
+func (l *layout) Broadcast(shape []int) *layout {
+	if len(shape) < l.Rank() {
+		panic(shapeError("BroadcastTo", shape, l.shape, ErrBroadcast))
+	}
+
+	b := new(layout)
+	b.shape = slice.Copy(shape)
+	b.strides = slice.WithLen[int](len(shape))
+	b.offset = l.offset
+
+	lead := len(shape) - l.Rank()
+	for i, d := range shape {
+		if d <= 0 {
+			panic(ErrBadShape)
+		}
+
+		if i < lead {
+			continue // new axes are repeated
+		}
+
+		switch l.shape[i-lead] {
+		case d:
+			b.strides[i] = l.strides[i-lead]
+		case 1:
+			b.strides[i] = 0
+		default:
+			panic(shapeError("BroadcastTo", shape, l.shape, ErrBroadcast))
+		}
+	}
+
+	return b
+}
+
+// Reshape returns a contiguous layout of the given shape starting at
+// the layout's offset, which must describe contiguous elements.
+// A single dimension of the shape may be -1, and is then inferred
+// from the number of elements and the other dimensions.
+func (l *layout) Reshape(shape []int) *layout {
+	if len(shape) == 0 && l.Numel() <= 1 {
+		c := newLayout(nil)
+		c.offset = l.offset
+
+		return c
+	}
+
+	shape = inferShape(shape, l.Numel())
+
+	assertGoodShape(shape...)
+	if slice.Prod(shape) != l.Numel() {
+		panic(shapeError("Reshape", l.shape, shape, ErrBadShape))
+	}
+
+	c := newLayout(slice.Copy(shape))
+	c.offset = l.offset
+
+	return c
+}
+
+// inferShape returns a copy of the given shape whose dimension of -1,
+// if any, is replaced by the one giving the shape n elements. The shape
+// is returned as is if it can't be inferred.
+func inferShape(shape []int, n int) []int {
+	axis, prod := -1, 1
+	for i, d := range shape {
+		switch {
+		case d == -1 && axis < 0:
+			axis = i
+		case d > 0:
+			prod *= d
+		default:
+			return shape
+		}
+	}
+
+	if axis < 0 || n%prod != 0 {
+		return shape
+	}
+
+	s := slice.Copy(shape)
+	s[axis] = n / prod
+
+	return s
+}
+
+// Flatten returns a layout over the same elements whose axes from
+// start to end, inclusive, are merged into one. It returns nil if the
+// merged axes cannot be walked with a single stride.
+func (l *layout) Flatten(start, end int) *layout {
+	assertAxisBounds(start, l.Rank())
+	assertAxisBounds(end, l.Rank())
+	if start > end {
+		panic(ErrBadAxes)
+	}
+
+	// the merged axes must be nested within each other,
+	// ignoring those of dimension 1 which are never walked
+	stride, size := 1, 1
+	for i := end; i >= start; i-- {
+		if l.shape[i] == 1 {
+			continue
+		}
+
+		if size == 1 {
+			stride = l.strides[i]
+		} else if l.strides[i] != stride*size {
+			return nil
+		}
+
+		size *= l.shape[i]
+	}
+
+	c := new(layout)
+	c.shape = append(append(slice.Copy(l.shape[:start]), size), l.shape[end+1:]...)
+	c.strides = append(append(slice.Copy(l.strides[:start]), stride), l.strides[end+1:]...)
+	c.offset = l.offset
+
+	return c
+}
+
+// Permute returns a layout over the same elements whose i-th
+// axis is the axes[i]-th axis of the layout.
+func (l *layout) Permute(axes []int) *layout {
+	assertPermutation(axes, l.Rank())
+
+	c := new(layout)
+	c.shape = slice.WithLen[int](l.Rank())
+	c.strides = slice.WithLen[int](l.Rank())
+	c.offset = l.offset
+
+	for i, a := range axes {
+		c.shape[i] = l.shape[a]
+		c.strides[i] = l.strides[a]
+	}
+
+	return c
+}
+
+// Squeeze returns a layout over the same elements without the given
+// axes, which must have a dimension of 1, or without all the axes of
+// dimension 1 if none are given.
+func (l *layout) Squeeze(axes []int) *layout {
+	drop := make([]bool, l.Rank())
+	if len(axes) == 0 {
+		for i, d := range l.shape {
+			drop[i] = d == 1
+		}
+	}
+
+	for _, a := range axes {
+		assertAxisBounds(a, l.Rank())
+		if drop[a] || l.shape[a] != 1 {
+			panic(ErrBadAxes)
+		}
+		drop[a] = true
+	}
+
+	c := new(layout)
+	c.offset = l.offset
+
+	for i, d := range l.shape {
+		if !drop[i] {
+			c.shape = append(c.shape, d)
+			c.strides = append(c.strides, l.strides[i])
+		}
+	}
+
+	return c
+}
+
+// Unsqueeze returns a layout over the same elements
+// with an axis of dimension 1 inserted at the given position.
+func (l *layout) Unsqueeze(axis int) *layout {
+	assertAxisBounds(axis, l.Rank()+1)
+
+	c := new(layout)
+	c.shape = append(append(slice.Copy(l.shape[:axis]), 1), l.shape[axis:]...)
+	c.strides = append(append(slice.Copy(l.strides[:axis]), 0), l.strides[axis:]...)
+	c.offset = l.offset
+
+	return c
+}
+
+// Index returns a layout over the given index of the layout's leading axes.
+func (l *layout) Index(indices []int) *layout {
+	assertArgsBounds(len(indices), l.Rank())
+
+	offset := l.offset
+	for i, idx := range indices {
+		assertInRange(idx, 0, l.shape[i])
+		offset += idx * l.strides[i]
+	}
+
+	c := new(layout)
+	c.shape = slice.Copy(l.shape[len(indices):])
+	c.strides = slice.Copy(l.strides[len(indices):])
+	c.offset = offset
+
+	return c
+}
+
+// Slice returns a layout over the interval [start, end) of the
+// given axis of the layout, taking every step-th element.
+func (l *layout) Slice(axis, start, end, step int) *layout {
+	assertAxisBounds(axis, l.Rank())
+	assertGoodInterval(start, end, [2]int{0, l.shape[axis]})
+	assertGoodStep(step, start, end)
+
+	c := l.Copy()
+	c.shape[axis] = (end - start + step - 1) / step
+	c.strides[axis] *= step
+	c.offset += start * l.strides[axis]
+
+	return c
+}
+
+// Select returns a layout over the given index of the
+// given axis of the layout, without that axis.
+func (l *layout) Select(axis, index int) *layout {
+	assertAxisBounds(axis, l.Rank())
+	assertInRange(index, 0, l.shape[axis])
+
+	c := new(layout)
+	c.shape = append(slice.Copy(l.shape[:axis]), l.shape[axis+1:]...)
+	c.strides = append(slice.Copy(l.strides[:axis]), l.strides[axis+1:]...)
+	c.offset = l.offset + index*l.strides[axis]
+
+	return c
+}
+
+// Subscripts returns a layout over the elements of the
+// layout selected by the given Subscripts, as described by Sl.
+func (l *layout) Subscripts(subs []Subscript) *layout {
+	fail := func(s Subscript, axis int, err error) {
+		panic(&SliceError{Expr: s.String(), Axis: axis, Shape: slice.Copy(l.shape), Err: err})
+	}
+
+	// count the axes the subscripts apply to,
+	// and make sure there is at most one ellipsis
+	consumed, ellipsis := 0, -1
+	for i, s := range subs {
+		switch s.kind {
+		case subIndex, subSlice:
+			consumed++
+		case subEllipsis:
+			if ellipsis >= 0 {
+				fail(s, -1, ErrBadSlice)
+			}
+			ellipsis = i
+		}
+	}
+
+	if consumed > l.Rank() {
+		panic(&SliceError{Expr: subscriptsString(subs), Axis: -1, Shape: slice.Copy(l.shape), Err: ErrBadSlice})
+	}
+
+	c := new(layout)
+	c.offset = l.offset
+
+	axis := 0
+	for _, s := range subs {
+		switch s.kind {
+		case subIndex:
+			n := l.shape[axis]
+
+			idx := s.index
+			if idx < 0 {
+				idx += n
+			}
+			if idx < 0 || idx >= n {
+				fail(s, axis, ErrIndexBounds)
+			}
+
+			c.offset += idx * l.strides[axis]
+			axis++
+		case subSlice:
+			if s.step == 0 {
+				fail(s, axis, ErrBadStep)
+			}
+
+			start, size := s.indices(l.shape[axis])
+			if size == 0 {
+				fail(s, axis, ErrBadInterval)
+			}
+
+			c.offset += start * l.strides[axis]
+			c.shape = append(c.shape, size)
+			c.strides = append(c.strides, l.strides[axis]*s.step)
+			axis++
+		case subNewAxis:
+			c.shape = append(c.shape, 1)
+			c.strides = append(c.strides, 0)
+		case subEllipsis:
+			whole := l.Rank() - consumed
+			c.shape = append(c.shape, l.shape[axis:axis+whole]...)
+			c.strides = append(c.strides, l.strides[axis:axis+whole]...)
+			axis += whole
+		}
+	}
+
+	c.shape = append(c.shape, l.shape[axis:]...)
+	c.strides = append(c.strides, l.strides[axis:]...)
+
+	if len(c.shape) == 0 {
+		c.shape, c.strides = nil, nil
+	}
+
+	return c
+}
+
 func (l *layout) Copy() *layout {
 	c := new(layout)
 	c.shape = slice.Copy(l.shape)
 	c.strides = slice.Copy(l.strides)
+	c.offset = l.offset
 
 	return c
-}
\ No newline at end of file
+}
@@ -6,120 +6,236 @@ package tensor
 
 import (
 	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
 	"github.com/lordlarker/nune/internal/slice"
 )
 
 // Cast casts a Tensor's underlying type to the given numeric type.
 func Cast[T nune.Numeric, U nune.Numeric](t *Tensor[U]) *Tensor[T] {
-	c := slice.WithLen[T](t.Numel())
+	data := t.flat()
+
+	c := slice.WithLen[T](len(data))
 	for i := 0; i < len(c); i++ {
-		c[i] = T(t.storage.Index(i))
+		c[i] = T(data[i])
 	}
 
 	return &Tensor[T]{
-		storage:    newStorage(c),
-		layout:   t.layout,
+		storage: newStorage(c),
+		layout:  newLayout(t.Shape()),
 	}
 }
 
-// Copy copies the Tensor's fields into
-// a new Tensor and returns it.
+// Copy copies the Tensor's elements into a new,
+// contiguous Tensor and returns it.
 func (t *Tensor[T]) Copy() *Tensor[T] {
 	return &Tensor[T]{
-		storage: t.storage.Copy(),
-		layout: t.layout.Copy(),
+		storage: newStorage(t.Ravel()),
+		layout:  newLayout(t.Shape()),
 	}
 }
 
 // Assign attempts to unwrap the given value and assign
-// the Tensor's data buffer to it, if it matches the Tensor's shape.
+// the Tensor's elements to it, if it matches the Tensor's shape.
+// Since views share their storage, the assignment is visible
+// through every view of the Tensor.
 func (t *Tensor[T]) Assign(v any) *Tensor[T] {
-	defer func() {
-		if r := recover(); r != nil {
-			panic(errStorageDump)
-		}
-	}()
+	assertWritable(t)
 
 	other := From[T](v)
-	if slice.Equal(t.layout.Shape(), other.layout.Shape()) {
-		t.storage.Dump(other.storage.Load())
-		return t
-	} else {
-		panic("") // trigger a recover then panic with the same error above
+	if !slice.Equal(t.layout.Shape(), other.layout.Shape()) {
+		panic(shapeError("Assign", t.layout.Shape(), other.layout.Shape(), ErrShapeMismatch))
 	}
+
+	cpd.Copy(t.layout.Shape(), other.span(), t.span())
+
+	return t
 }
 
-// Reshape modifies the Tensor's underlying shape buffer
-// and returns the Tensor.
+// Reshape returns a Tensor with the given shape sharing
+// the Tensor's storage, unless the Tensor is not contiguous,
+// in which case its elements are copied first. A single
+// dimension may be -1, and is then inferred from the
+// Tensor's number of elements and the other dimensions.
 func (t *Tensor[T]) Reshape(s ...int) *Tensor[T] {
-	if len(s) == 0 && t.Numel() <= 1 {
-		return &Tensor[T]{
-			storage: t.storage,
-			layout: newLayout(nil),
-		}
-	} else {
-		assertGoodShape(s...)
-		assertArgsBounds(len(s), t.Rank()-1)
-
-		return &Tensor[T]{
-			storage: t.storage,
-			layout: newLayout(slice.Copy(s)),
-		}
+	if !t.layout.Contiguous() {
+		t = t.Copy()
 	}
+
+	return t.view(t.layout.Reshape(s))
 }
 
 // Index returns a view over an index of the Tensor.
 func (t *Tensor[T]) Index(indices ...int) *Tensor[T] {
-	assertArgsBounds(len(indices), t.Rank())
+	return t.view(t.layout.Index(indices))
+}
+
+// Slice returns a view over a slice of the Tensor
+// on the interval [start, end) of its first axis.
+func (t *Tensor[T]) Slice(start, end int) *Tensor[T] {
+	assertGoodShape(t.Shape()...) // make sure Tensor rank is not 0
 
-	for i, idx := range indices {
-		assertAxisBounds(idx, t.Size(i))
+	return t.SliceAxis(0, start, end, 1)
+}
+
+// SliceAxis returns a view over a slice of the Tensor
+// on the interval [start, end) of the given axis,
+// taking every step-th element.
+func (t *Tensor[T]) SliceAxis(axis, start, end, step int) *Tensor[T] {
+	return t.view(t.layout.Slice(axis, start, end, step))
+}
+
+// BroadcastShapes returns the shape resulting from broadcasting
+// the two given shapes together, following NumPy's rules: shapes are
+// aligned on their last axis, and axes whose dimensions differ
+// must have a dimension of 1 on one side.
+func BroadcastShapes(a, b []int) []int {
+	long, short := a, b
+	if len(long) < len(short) {
+		long, short = short, long
 	}
 
-	var offset int
+	shape := slice.Copy(long)
+	lead := len(long) - len(short)
+
+	for i, d := range short {
+		switch {
+		case d <= 0 || long[lead+i] <= 0:
+			panic(ErrBadShape)
+		case d == long[lead+i] || d == 1:
+			continue
+		case long[lead+i] == 1:
+			shape[lead+i] = d
+		default:
+			panic(shapeError("BroadcastShapes", a, b, ErrBroadcast))
+		}
+	}
 
-	for i, idx := range indices {
-		offset += idx * t.layout.Strides()[i]
+	return shape
+}
+
+// BroadcastTo returns a view over the Tensor broadcasted to
+// the given shape, without copying its elements.
+// Since repeated elements share the same storage position,
+// writing to the returned view panics with ErrOverlap.
+func (t *Tensor[T]) BroadcastTo(shape ...int) *Tensor[T] {
+	return t.view(t.layout.Broadcast(shape))
+}
+
+// Transpose returns a view over the Tensor
+// with the order of its axes reversed.
+func (t *Tensor[T]) Transpose() *Tensor[T] {
+	axes := slice.WithLen[int](t.Rank())
+	for i := range axes {
+		axes[i] = t.Rank() - 1 - i
 	}
 
-	return &Tensor[T]{
-		storage: newStorage(t.storage.Load()[offset : offset+t.layout.Strides()[len(indices)-1]]),
-		layout: newLayout(slice.Copy(t.layout.Shape()[len(indices):])),
+	return t.Permute(axes...)
+}
+
+// Permute returns a view over the Tensor with its axes
+// reordered such that the i-th axis of the view is
+// the axes[i]-th axis of the Tensor.
+func (t *Tensor[T]) Permute(axes ...int) *Tensor[T] {
+	return t.view(t.layout.Permute(axes))
+}
+
+// MoveAxis returns a view over the Tensor whose src-th axis
+// is moved to the dst-th position, the other axes keeping
+// their order.
+func (t *Tensor[T]) MoveAxis(src, dst int) *Tensor[T] {
+	assertAxisBounds(src, t.Rank())
+	assertAxisBounds(dst, t.Rank())
+
+	axes := slice.WithCap[int](t.Rank())
+	for a := 0; a < t.Rank(); a++ {
+		if a != src {
+			axes = append(axes, a)
+		}
 	}
-	// return Tensor[T]{
-	// 	data:    t.data[offset : offset+t.strides[len(indices)-1]],
-	// 	shape:   slice.Copy(t.shape[len(indices):]),
-	// 	strides: stridesFromShape(t.shape[len(indices):]),
-	// }
+
+	axes = append(axes[:dst], append([]int{src}, axes[dst:]...)...)
+
+	return t.Permute(axes...)
 }
 
-// Slice returns a view over a slice of the Tensor.
-func (t *Tensor[T]) Slice(start, end int) *Tensor[T] {
-	assertGoodShape(t.Shape()...) // make sure Tensor rank is not 0
-	assertGoodInterval(start, end, [2]int{0, t.Size(0)})
+// SwapAxes returns a view over the Tensor
+// with its axes a and b interchanged.
+func (t *Tensor[T]) SwapAxes(a, b int) *Tensor[T] {
+	assertAxisBounds(a, t.Rank())
+	assertAxisBounds(b, t.Rank())
 
-	newshape := slice.WithLen[int](t.Rank())
-	newshape[0] = end - start
-	copy(newshape[1:], t.layout.Shape()[1:])
+	axes := slice.WithLen[int](t.Rank())
+	for i := range axes {
+		axes[i] = i
+	}
+	axes[a], axes[b] = b, a
 
-	return &Tensor[T]{
-		storage: newStorage(t.storage.Load()[start*t.layout.Strides()[0] : end*t.layout.Strides()[0]]),
-		layout: newLayout(newshape),
+	return t.Permute(axes...)
+}
+
+// Flatten returns a Tensor whose axes from start to end, inclusive,
+// are merged into a single axis. It is a view over the Tensor unless
+// the merged axes can't be walked with a single stride, in which
+// case the Tensor's elements are copied first.
+func (t *Tensor[T]) Flatten(start, end int) *Tensor[T] {
+	if l := t.layout.Flatten(start, end); l != nil {
+		return t.view(l)
 	}
 
-	// return Tensor[T]{
-	// 	data:    t.data[start*t.strides[0] : end*t.strides[0]],
-	// 	shape:   newshape,
-	// 	strides: stridesFromShape(newshape),
-	// }
+	return t.Copy().Flatten(start, end)
+}
+
+// Squeeze returns a view over the Tensor without the given axes,
+// which must have a dimension of 1, or without all its axes
+// of dimension 1 if none are given.
+func (t *Tensor[T]) Squeeze(axes ...int) *Tensor[T] {
+	return t.view(t.layout.Squeeze(axes))
+}
+
+// Unsqueeze returns a view over the Tensor with an axis
+// of dimension 1 inserted at the given position.
+func (t *Tensor[T]) Unsqueeze(axis int) *Tensor[T] {
+	return t.view(t.layout.Unsqueeze(axis))
+}
+
+// Expand returns a view over the Tensor broadcasted to the given
+// shape, as BroadcastTo does, except that a dimension of -1 keeps
+// the dimension of the matching axis of the Tensor. Since repeated
+// elements share the same storage position, writing to the
+// returned view panics with ErrOverlap.
+func (t *Tensor[T]) Expand(shape ...int) *Tensor[T] {
+	s := slice.Copy(shape)
+
+	lead := len(s) - t.Rank()
+	for i := range s {
+		if s[i] == -1 && i >= lead {
+			s[i] = t.layout.Shape()[i-lead]
+		}
+	}
+
+	return t.BroadcastTo(s...)
+}
+
+// Contiguous returns the Tensor if it is contiguous,
+// or a contiguous copy of it otherwise.
+func (t *Tensor[T]) Contiguous() *Tensor[T] {
+	if t.layout.Contiguous() {
+		return t
+	}
+
+	return t.Copy()
 }
 
 // Reverse reverses the order of the elements of the Tensor.
 func (t *Tensor[T]) Reverse() *Tensor[T] {
-	for i, j := 0, t.Numel()-1; i < j; i, j = i+1, j-1 {
-		t.storage.SetIndex(i, t.storage.Index(j))
-		t.storage.SetIndex(j, t.storage.Index(i))
+	assertWritable(t)
+
+	data := t.Ravel()
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
 	}
 
+	cpd.Copy(t.layout.Shape(), cpd.Flat(data, t.layout.Shape()), t.span())
+
 	return t
 }
@@ -0,0 +1,175 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+func TestStridedViews(t *testing.T) {
+	x := Range[int](0, 24, 1).Reshape(2, 3, 4)
+
+	tests := []struct {
+		name    string
+		view    *Tensor[int]
+		want    *Tensor[int]
+		strides []int
+		offset  int
+	}{
+		{"index", x.Index(1), Range[int](12, 24, 1).Reshape(3, 4), []int{4, 1}, 12},
+		{"nested index", x.Index(1, 2), Range[int](20, 24, 1), []int{1}, 20},
+		{"slice", x.Slice(1, 2), Range[int](12, 24, 1).Reshape(1, 3, 4), []int{12, 4, 1}, 12},
+		{"stepped slice", x.SliceAxis(2, 1, 4, 2), FromBuffer([]int{
+			1, 3, 5, 7, 9, 11,
+			13, 15, 17, 19, 21, 23,
+		}, 2, 3, 2), []int{12, 4, 2}, 1},
+		{"transpose", x.Index(0).Transpose(), FromBuffer([]int{
+			0, 4, 8,
+			1, 5, 9,
+			2, 6, 10,
+			3, 7, 11,
+		}, 4, 3), []int{1, 4}, 0},
+		{"permute", x.Permute(2, 0, 1).Index(3), FromBuffer([]int{
+			3, 7, 11,
+			15, 19, 23,
+		}, 2, 3), []int{12, 4}, 3},
+	}
+
+	for _, tt := range tests {
+		if !equal(tt.view, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.view, tt.want)
+		}
+
+		if !slice.Equal(tt.view.Strides(), tt.strides) || tt.view.Offset() != tt.offset {
+			t.Errorf("%s: got strides %v and offset %d, want %v and %d",
+				tt.name, tt.view.Strides(), tt.view.Offset(), tt.strides, tt.offset)
+		}
+	}
+}
+
+func TestViewAliasing(t *testing.T) {
+	tests := []struct {
+		name  string
+		view  func(*Tensor[int]) *Tensor[int]
+		value any
+		want  []int
+	}{
+		{"index", func(x *Tensor[int]) *Tensor[int] {
+			return x.Index(1)
+		}, []int{-1, -2, -3}, []int{0, 1, 2, -1, -2, -3}},
+		{"transpose", func(x *Tensor[int]) *Tensor[int] {
+			return x.Transpose().Index(0)
+		}, []int{-1, -2}, []int{-1, 1, 2, -2, 4, 5}},
+		{"permute", func(x *Tensor[int]) *Tensor[int] {
+			return x.Permute(1, 0).SliceAxis(0, 0, 3, 2)
+		}, [][]int{{-1, -2}, {-3, -4}}, []int{-1, 1, -3, -2, 4, -4}},
+	}
+
+	for _, tt := range tests {
+		x := Range[int](0, 6, 1).Reshape(2, 3)
+		tt.view(x).Assign(tt.value)
+
+		if got := x.Ravel(); !slice.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	x := Range[int](0, 6, 1).Reshape(2, 3)
+	y := x.Transpose()
+	x.Index(0).Assign([]int{7, 8, 9})
+
+	if want := FromBuffer([]int{7, 3, 8, 4, 9, 5}, 3, 2); !equal(y, want) {
+		t.Errorf("transposed view: got %v, want %v", y, want)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name string
+		view func(*Tensor[int]) *Tensor[int]
+		want []int
+	}{
+		{"whole", func(x *Tensor[int]) *Tensor[int] {
+			return x
+		}, []int{5, 4, 3, 2, 1, 0}},
+		{"row", func(x *Tensor[int]) *Tensor[int] {
+			return x.Index(1)
+		}, []int{0, 1, 2, 5, 4, 3}},
+		{"column", func(x *Tensor[int]) *Tensor[int] {
+			return x.Transpose().Index(1)
+		}, []int{0, 4, 2, 3, 1, 5}},
+		{"transposed", func(x *Tensor[int]) *Tensor[int] {
+			return x.Transpose()
+		}, []int{5, 4, 3, 2, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		x := Range[int](0, 6, 1).Reshape(2, 3)
+		tt.view(x).Reverse()
+
+		if got := x.Ravel(); !slice.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBroadcastShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want []int
+		err  error
+	}{
+		{"same", []int{2, 3}, []int{2, 3}, []int{2, 3}, nil},
+		{"scalar", []int{2, 3}, nil, []int{2, 3}, nil},
+		{"lower rank", []int{4, 1, 3}, []int{2, 1}, []int{4, 2, 3}, nil},
+		{"unit axes", []int{1, 3}, []int{2, 1}, []int{2, 3}, nil},
+		{"mismatch", []int{2, 3}, []int{3, 2}, nil, ErrBroadcast},
+		{"bad shape", []int{2, 0}, []int{2, 1}, nil, ErrBadShape},
+	}
+
+	for _, tt := range tests {
+		for _, args := range [][2][]int{{tt.a, tt.b}, {tt.b, tt.a}} {
+			got, err := TryBroadcastShapes(args[0], args[1])
+			if !errors.Is(err, tt.err) || !slice.Equal(got, tt.want) {
+				t.Errorf("%s: got %v, %v, want %v, %v", tt.name, got, err, tt.want, tt.err)
+			}
+		}
+	}
+}
+
+func TestBroadcastTo(t *testing.T) {
+	x := FromBuffer([]int{1, 2, 3}, 3, 1)
+
+	tests := []struct {
+		name    string
+		shape   []int
+		want    *Tensor[int]
+		strides []int
+		err     error
+	}{
+		{"unit axis", []int{3, 2}, FromBuffer([]int{1, 1, 2, 2, 3, 3}, 3, 2), []int{1, 0}, nil},
+		{"new axis", []int{2, 3, 1}, FromBuffer([]int{1, 2, 3, 1, 2, 3}, 2, 3, 1), []int{0, 1, 1}, nil},
+		{"same shape", []int{3, 1}, x, []int{1, 1}, nil},
+		{"bad dimension", []int{2, 1}, nil, nil, ErrBroadcast},
+		{"lower rank", []int{3}, nil, nil, ErrBroadcast},
+	}
+
+	for _, tt := range tests {
+		got, err := x.TryBroadcastTo(tt.shape...)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+
+		if err == nil && (!equal(got, tt.want) || !slice.Equal(got.Strides(), tt.strides)) {
+			t.Errorf("%s: got %v with strides %v, want %v with strides %v",
+				tt.name, got, got.Strides(), tt.want, tt.strides)
+		}
+	}
+}
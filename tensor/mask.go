@@ -0,0 +1,301 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// A Mask is an n-dimensional tensor of booleans, such as the
+// results of comparisons between Tensors, which selects the
+// elements of Tensors it is broadcastable to.
+type Mask struct {
+	storage *cpd.Storage[uint8] // the storage that holds the Mask's values, as 1 or 0
+	layout  *layout             // the layout that holds the Mask's indexing scheme
+}
+
+// MaskFromBuffer returns a Mask of the given shape holding
+// the given booleans in row-major order.
+func MaskFromBuffer(data []bool, shape ...int) *Mask {
+	if len(shape) != 0 {
+		assertGoodShape(shape...)
+	}
+
+	if len(data) != slice.Prod(shape) {
+		panic(shapeError("MaskFromBuffer", shape, []int{len(data)}, ErrBadShape))
+	}
+
+	buf := slice.WithLen[uint8](len(data))
+	for i, b := range data {
+		if b {
+			buf[i] = 1
+		}
+	}
+
+	return &Mask{
+		storage: cpd.NewStorage(buf),
+		layout:  newLayout(slice.Copy(shape)),
+	}
+}
+
+// FromMask returns a Tensor holding ones where
+// the Mask is true, and zeros elsewhere.
+func FromMask[T nune.Numeric](m *Mask) *Tensor[T] {
+	data := m.flat()
+
+	buf := slice.WithLen[T](len(data))
+	for i, b := range data {
+		buf[i] = T(b)
+	}
+
+	return FromBuffer(buf, m.Shape()...)
+}
+
+// span returns the strided span through which
+// the Mask walks its storage.
+func (m *Mask) span() cpd.Span[uint8] {
+	return cpd.Span[uint8]{
+		Buf:     m.storage.Load(),
+		Strides: m.layout.Strides(),
+		Offset:  m.layout.Offset(),
+	}
+}
+
+// flat returns the Mask's values in row-major order, as 1 or 0,
+// avoiding a copy when the Mask is contiguous.
+// The returned buffer must be treated as read-only.
+func (m *Mask) flat() []uint8 {
+	if m.layout.Contiguous() {
+		return m.storage.Slice(m.layout.Offset(), m.layout.Offset()+m.layout.Numel())
+	}
+
+	data := slice.WithLen[uint8](m.Numel())
+	cpd.Copy(m.layout.Shape(), m.span(), cpd.Flat(data, m.layout.Shape()))
+
+	return data
+}
+
+// Ravel returns a copy of the Mask's values
+// as a 1-dimensional buffer, in row-major order.
+func (m *Mask) Ravel() []bool {
+	data := m.flat()
+
+	buf := make([]bool, len(data))
+	for i, b := range data {
+		buf[i] = b != 0
+	}
+
+	return buf
+}
+
+// Numel returns the number of values in the Mask.
+func (m *Mask) Numel() int {
+	return m.layout.Numel()
+}
+
+// Rank returns the Mask's rank
+// (the number of axes in the Mask's shape).
+func (m *Mask) Rank() int {
+	return m.layout.Rank()
+}
+
+// Shape returns a copy of the Mask's shape.
+func (m *Mask) Shape() []int {
+	return slice.Copy(m.layout.Shape())
+}
+
+// BroadcastTo returns a view over the Mask broadcasted
+// to the given shape, without copying its values.
+func (m *Mask) BroadcastTo(shape ...int) *Mask {
+	return &Mask{
+		storage: m.storage,
+		layout:  m.layout.Broadcast(shape),
+	}
+}
+
+// Any returns whether or not any of the Mask's values is true.
+func (m *Mask) Any() bool {
+	return m.CountNonzero() > 0
+}
+
+// All returns whether or not all of the Mask's values are true.
+func (m *Mask) All() bool {
+	return m.CountNonzero() == m.Numel()
+}
+
+// CountNonzero returns the number of true values in the Mask.
+func (m *Mask) CountNonzero() int {
+	return cpd.CountNonzero(m.layout.Shape(), m.span())
+}
+
+// CountNonzero returns the number of non-zero elements in the Tensor.
+func (t *Tensor[T]) CountNonzero() int {
+	return cpd.CountNonzero(t.layout.Shape(), t.span())
+}
+
+// Eq compares the elements of the two Tensors, broadcasted together,
+// and returns whether they are equal in a new Mask, or in out if it
+// is provided.
+func Eq[T nune.Numeric](a, b *Tensor[T], out ...*Mask) *Mask {
+	return compare("Eq", a, b, out, func(x, y T) bool { return x == y })
+}
+
+// Ne compares the elements of the two Tensors, broadcasted together,
+// and returns whether they are not equal in a new Mask, or in out if it
+// is provided.
+func Ne[T nune.Numeric](a, b *Tensor[T], out ...*Mask) *Mask {
+	return compare("Ne", a, b, out, func(x, y T) bool { return x != y })
+}
+
+// Lt compares the elements of the two Tensors, broadcasted together,
+// and returns whether those of a are less than those of b in a new
+// Mask, or in out if it is provided.
+func Lt[T nune.Numeric](a, b *Tensor[T], out ...*Mask) *Mask {
+	return compare("Lt", a, b, out, func(x, y T) bool { return x < y })
+}
+
+// Le compares the elements of the two Tensors, broadcasted together,
+// and returns whether those of a are less than or equal to those of b
+// in a new Mask, or in out if it is provided.
+func Le[T nune.Numeric](a, b *Tensor[T], out ...*Mask) *Mask {
+	return compare("Le", a, b, out, func(x, y T) bool { return x <= y })
+}
+
+// Gt compares the elements of the two Tensors, broadcasted together,
+// and returns whether those of a are greater than those of b in a new
+// Mask, or in out if it is provided.
+func Gt[T nune.Numeric](a, b *Tensor[T], out ...*Mask) *Mask {
+	return compare("Gt", a, b, out, func(x, y T) bool { return x > y })
+}
+
+// Ge compares the elements of the two Tensors, broadcasted together,
+// and returns whether those of a are greater than or equal to those
+// of b in a new Mask, or in out if it is provided.
+func Ge[T nune.Numeric](a, b *Tensor[T], out ...*Mask) *Mask {
+	return compare("Ge", a, b, out, func(x, y T) bool { return x >= y })
+}
+
+// compare applies the predicate f element-wise over the Tensors
+// a and b broadcasted together, and returns the results in a Mask.
+func compare[T nune.Numeric](op string, a, b *Tensor[T], out []*Mask, f func(T, T) bool) *Mask {
+	shape := BroadcastShapes(a.layout.Shape(), b.layout.Shape())
+	res := maskResult(op, shape, out)
+
+	cpd.Compare(shape, a.BroadcastTo(shape...).span(), b.BroadcastTo(shape...).span(), res.span(), f)
+
+	return res
+}
+
+// And returns the logical conjunction of the two Masks, broadcasted
+// together, in a new Mask, or in out if it is provided.
+func And(a, b *Mask, out ...*Mask) *Mask {
+	return logical("And", a, b, out, func(x, y uint8) uint8 { return x & y })
+}
+
+// Or returns the logical disjunction of the two Masks, broadcasted
+// together, in a new Mask, or in out if it is provided.
+func Or(a, b *Mask, out ...*Mask) *Mask {
+	return logical("Or", a, b, out, func(x, y uint8) uint8 { return x | y })
+}
+
+// Xor returns the logical exclusive disjunction of the two Masks,
+// broadcasted together, in a new Mask, or in out if it is provided.
+func Xor(a, b *Mask, out ...*Mask) *Mask {
+	return logical("Xor", a, b, out, func(x, y uint8) uint8 { return x ^ y })
+}
+
+// Not returns the logical negation of the Mask
+// in a new Mask, or in out if it is provided.
+func Not(m *Mask, out ...*Mask) *Mask {
+	res := maskResult("Not", m.layout.Shape(), out)
+	cpd.Pointwise(m.layout.Shape(), m.span(), res.span(), func(x uint8) uint8 {
+		return x ^ 1
+	})
+
+	return res
+}
+
+// logical applies f element-wise over the Masks a and b broadcasted
+// together, and returns the results in a Mask.
+func logical(op string, a, b *Mask, out []*Mask, f func(uint8, uint8) uint8) *Mask {
+	shape := BroadcastShapes(a.layout.Shape(), b.layout.Shape())
+	res := maskResult(op, shape, out)
+
+	cpd.Op(shape, a.BroadcastTo(shape...).span(), b.BroadcastTo(shape...).span(), res.span(), f)
+
+	return res
+}
+
+// maskResult returns the Mask into which an operation whose results
+// have the given shape writes: either the given out Mask, or a new
+// one if it isn't provided, as result does for Tensors.
+func maskResult(op string, shape []int, out []*Mask) *Mask {
+	assertArgsBounds(len(out), 1)
+
+	if len(out) == 1 {
+		if out[0].layout.Overlapping() {
+			panic(ErrOverlap)
+		}
+		if !slice.Equal(out[0].layout.Shape(), shape) {
+			panic(shapeError(op, shape, out[0].layout.Shape(), ErrBadShape))
+		}
+
+		return out[0]
+	}
+
+	return &Mask{
+		storage: cpd.NewStorage(slice.WithLen[uint8](slice.Prod(shape))),
+		layout:  newLayout(slice.Copy(shape)),
+	}
+}
+
+// Where returns the elements of a where the Mask is true, and those
+// of b elsewhere, the Mask and the Tensors being broadcasted together,
+// in a new Tensor, or in out if it is provided.
+func Where[T nune.Numeric](mask *Mask, a, b *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	shape := BroadcastShapes(mask.layout.Shape(), BroadcastShapes(a.layout.Shape(), b.layout.Shape()))
+	res := result("Where", shape, out)
+
+	cpd.Select(shape, mask.BroadcastTo(shape...).span(), a.BroadcastTo(shape...).span(), b.BroadcastTo(shape...).span(), res.span())
+
+	return res
+}
+
+// MaskedSelect returns the elements of the Tensor where the Mask,
+// broadcasted to the Tensor's shape, is true, in row-major order,
+// in a new rank 1 Tensor. Since Tensors can't be empty, it returns
+// false, and a nil Tensor, if the Mask is all false.
+func MaskedSelect[T nune.Numeric](t *Tensor[T], mask *Mask) (*Tensor[T], bool) {
+	data := cpd.Compact(t.flat(), mask.BroadcastTo(t.layout.Shape()...).flat())
+	if len(data) == 0 {
+		return nil, false
+	}
+
+	return FromBuffer(data, len(data)), true
+}
+
+// MaskedFill returns a copy of the Tensor whose elements are replaced
+// by the given value where the Mask, broadcasted to the Tensor's shape,
+// is true, in a new Tensor, or in out if it is provided.
+func MaskedFill[T nune.Numeric](t *Tensor[T], mask *Mask, x T, out ...*Tensor[T]) *Tensor[T] {
+	shape := t.layout.Shape()
+	res := result("MaskedFill", shape, out)
+
+	fill := FromBuffer([]T{x})
+	fill.layout = fill.layout.Broadcast(shape)
+
+	cpd.Select(shape, mask.BroadcastTo(shape...).span(), fill.span(), t.span(), res.span())
+
+	return res
+}
+
+// MaskedFillInPlace replaces, by reference, the Tensor's elements by
+// the given value where the Mask, broadcasted to the Tensor's shape,
+// is true, and then returns the Tensor.
+func (t *Tensor[T]) MaskedFillInPlace(mask *Mask, x T) *Tensor[T] {
+	return MaskedFill(t, mask, x, t)
+}
@@ -0,0 +1,49 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import "testing"
+
+func TestMaskedSelect(t *testing.T) {
+	x := Range[int](0, 6, 1).Reshape(2, 3)
+
+	tests := []struct {
+		name string
+		mask *Mask
+		want []int
+	}{
+		{"elements", Gt(x, From[int](2)), []int{3, 4, 5}},
+		{"broadcasted", MaskFromBuffer([]bool{true, false, true}, 3), []int{0, 2, 3, 5}},
+		{"none", Gt(x, From[int](9)), nil},
+	}
+
+	for _, tt := range tests {
+		res, ok := MaskedSelect(x, tt.mask)
+		if ok != (tt.want != nil) {
+			t.Fatalf("%s: got ok = %v", tt.name, ok)
+		}
+
+		if ok && !equal(res, FromBuffer(tt.want, len(tt.want))) {
+			t.Errorf("%s: got %v, want %v", tt.name, res, tt.want)
+		}
+	}
+}
+
+func TestWhereAndFill(t *testing.T) {
+	x := Range[int](0, 6, 1).Reshape(2, 3)
+	m := Lt(x, From[int](3))
+
+	if got := Where(m, x, Zeros[int](1)); !equal(got, FromBuffer([]int{0, 1, 2, 0, 0, 0}, 2, 3)) {
+		t.Errorf("Where: got %v", got)
+	}
+
+	if got := MaskedFill(x, Not(m), -1); !equal(got, FromBuffer([]int{0, 1, 2, -1, -1, -1}, 2, 3)) {
+		t.Errorf("MaskedFill: got %v", got)
+	}
+
+	if n := And(m, Ne(x, From[int](1))).CountNonzero(); n != 2 {
+		t.Errorf("And: got %d true values, want 2", n)
+	}
+}
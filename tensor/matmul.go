@@ -0,0 +1,115 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// MatMul returns the matrix product of the two Tensors in a new Tensor,
+// or in out if it is provided.
+//
+// Following NumPy's semantics, Tensors of rank 2 or more are treated as
+// stacks of matrices held in their last two axes, whose leading batch
+// axes are broadcasted together. A rank 1 Tensor a is promoted to a
+// matrix by prepending an axis of dimension 1 to its shape, and a rank 1
+// Tensor b by appending one, which is then removed from the result.
+func MatMul[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	assertGoodShape(a.layout.Shape()...)
+	assertGoodShape(b.layout.Shape()...)
+
+	va, vb := a.Rank() == 1, b.Rank() == 1
+	if va {
+		a = a.view(withAxis(a.layout, 0))
+	}
+	if vb {
+		b = b.view(withAxis(b.layout, 1))
+	}
+
+	m, k := a.Size(a.Rank()-2), a.Size(a.Rank()-1)
+	n := b.Size(b.Rank() - 1)
+	if b.Size(b.Rank()-2) != k {
+		expected := slice.Copy(b.layout.Shape())
+		expected[b.Rank()-2] = k
+
+		panic(shapeError("MatMul", expected, b.layout.Shape(), ErrShapeMismatch))
+	}
+
+	batch := BroadcastShapes(a.layout.Shape()[:a.Rank()-2], b.layout.Shape()[:b.Rank()-2])
+
+	shape := slice.Copy(batch)
+	if !va {
+		shape = append(shape, m)
+	}
+	if !vb {
+		shape = append(shape, n)
+	}
+
+	res := result("MatMul", shape, out)
+
+	// view the result with both matrix axes, whichever were removed
+	c := res.view(res.layout.Copy())
+	if va {
+		c.layout = withAxis(c.layout, len(batch))
+	}
+	if vb {
+		c.layout = withAxis(c.layout, len(batch)+1)
+	}
+
+	a = a.BroadcastTo(append(slice.Copy(batch), m, k)...)
+	b = b.BroadcastTo(append(slice.Copy(batch), k, n)...)
+
+	cpd.MatMul(batch, m, n, k, a.span(), b.span(), c.span())
+
+	return res
+}
+
+// MatVec returns the product of the matrix, or stack of matrices, a
+// and the rank 1 Tensor v in a new Tensor, or in out if it is provided.
+func MatVec[T nune.Numeric](a, v *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	if a.Rank() < 2 || v.Rank() != 1 {
+		panic(ErrBadShape)
+	}
+
+	return MatMul(a, v, out...)
+}
+
+// Dot returns the inner product of the two rank 1 Tensors.
+func Dot[T nune.Numeric](a, b *Tensor[T]) T {
+	if a.Rank() != 1 || b.Rank() != 1 {
+		panic(ErrBadShape)
+	}
+
+	return MatMul(a, b).storage.Index(0)
+}
+
+// Outer returns the outer product of the two rank 1 Tensors
+// in a new Tensor, or in out if it is provided.
+func Outer[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	if a.Rank() != 1 || b.Rank() != 1 {
+		panic(ErrBadShape)
+	}
+
+	return Mul(a.view(withAxis(a.layout, 1)), b, out...)
+}
+
+// withAxis returns a copy of the layout with a new axis
+// of dimension 1 inserted at the given position.
+func withAxis(l *layout, axis int) *layout {
+	c := new(layout)
+	c.shape = slice.WithLen[int](l.Rank() + 1)
+	c.strides = slice.WithLen[int](l.Rank() + 1)
+	c.offset = l.offset
+
+	copy(c.shape, l.shape[:axis])
+	copy(c.strides, l.strides[:axis])
+	c.shape[axis] = 1
+	copy(c.shape[axis+1:], l.shape[axis:])
+	copy(c.strides[axis+1:], l.strides[axis:])
+
+	return c
+}
@@ -0,0 +1,113 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// naiveMatMul returns the product of the matrices a and b
+// computed from its definition, one element at a time.
+func naiveMatMul(a, b *Tensor[float64]) *Tensor[float64] {
+	m, k, n := a.Size(0), a.Size(1), b.Size(1)
+	x, y := a.Ravel(), b.Ravel()
+
+	c := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			for p := 0; p < k; p++ {
+				c[i*n+j] += x[i*k+p] * y[p*n+j]
+			}
+		}
+	}
+
+	return FromBuffer(c, m, n)
+}
+
+func TestMatMulBlocks(t *testing.T) {
+	// sizes on either side of the edges of the 64×128×256 blocks
+	tests := []struct {
+		m, k, n int
+	}{
+		{1, 1, 1},
+		{3, 5, 2},
+		{64, 256, 128},
+		{65, 257, 129},
+		{63, 255, 127},
+		{130, 3, 257},
+		{1, 513, 1},
+		{129, 1, 1},
+	}
+
+	for _, tt := range tests {
+		a, b := filled(1, tt.m, tt.k), filled(2, tt.k, tt.n)
+		want := naiveMatMul(a, b)
+
+		if got := MatMul(a, b); !equal(got, want) {
+			t.Errorf("%d×%d×%d: got a different product", tt.m, tt.k, tt.n)
+		}
+
+		// strided operands multiply as their copies
+		at, bt := a.Transpose().Copy().Transpose(), b.Transpose().Copy().Transpose()
+		if got := MatMul(at, bt); !equal(got, want) {
+			t.Errorf("%d×%d×%d: strided: got a different product", tt.m, tt.k, tt.n)
+		}
+	}
+}
+
+func TestMatMulBatch(t *testing.T) {
+	const m, k, n = 70, 260, 3
+
+	a := filled(1, 2, 1, m, k)
+	b := filled(2, 3, k, n)
+
+	got := MatMul(a, b)
+	if want := []int{2, 3, m, n}; !slice.Equal(got.Shape(), want) {
+		t.Fatalf("got shape %v, want %v", got.Shape(), want)
+	}
+
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			if want := naiveMatMul(a.Index(i, 0), b.Index(j)); !equal(got.Index(i, j), want) {
+				t.Errorf("batch (%d, %d): got a different product", i, j)
+			}
+		}
+	}
+
+	// a rank 1 operand is promoted, then its axis removed
+	v := filled(3, k)
+	if got, want := MatMul(v, b), MatMul(v.Unsqueeze(0), b).Squeeze(1); !equal(got, want) {
+		t.Errorf("vector times batch: got %v, want %v", got, want)
+	}
+	if got, want := MatMul(a, v), MatMul(a, v.Unsqueeze(1)).Squeeze(3); !equal(got, want) {
+		t.Errorf("batch times vector: got %v, want %v", got, want)
+	}
+
+	out := Zeros[float64](2, 3, m, n)
+	if res := MatMul(a, b, out); res != out || !equal(out, got) {
+		t.Errorf("out: got a different product")
+	}
+}
+
+func TestMatMulBadShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Tensor[float64]
+		err  error
+	}{
+		{"inner", Ones[float64](2, 3), Ones[float64](2, 3), ErrShapeMismatch},
+		{"vectors", Ones[float64](3), Ones[float64](4), ErrShapeMismatch},
+		{"batch", Ones[float64](2, 2, 3), Ones[float64](3, 3, 2), ErrBroadcast},
+	}
+
+	for _, tt := range tests {
+		if _, err := TryMatMul(tt.a, tt.b); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// npyMagic is the magic string opening every npy file.
+const npyMagic = "\x93NUMPY"
+
+// npyAlign is the alignment of the data following npy headers.
+const npyAlign = 64
+
+// npyChunk is the size of the chunks in which npy data is read.
+const npyChunk = 1 << 16
+
+// npyMaxHeader is the maximum size of the headers of the npy files
+// read, which is NumPy's own default limit.
+const npyMaxHeader = 10000
+
+// nativeEndian is the byte order of the host.
+var nativeEndian = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+
+	return binary.BigEndian
+}()
+
+// ReadNpy reads a Tensor in NumPy's npy format from the given reader.
+// Arrays of any integer, floating-point or boolean dtype and of any
+// byte order are supported, and their elements are converted to T
+// as Cast would. Arrays in Fortran order are returned in row-major order.
+// Malformed or truncated files are reported with errors wrapping ErrBadNpy.
+//
+// Headers are bounded in size, and the data following them is read
+// incrementally. When the reader is an io.Seeker, both are first checked
+// to fit in what remains of it, such that a malformed header can't
+// cause a huge allocation.
+func ReadNpy[T nune.Numeric](r io.Reader) (*Tensor[T], error) {
+	limit := int64(-1)
+	if s, ok := r.(io.Seeker); ok {
+		n, err := remaining(s)
+		if err != nil {
+			return nil, err
+		}
+		limit = n
+	}
+
+	return readNpy[T](r, limit)
+}
+
+// remaining returns the number of bytes between
+// the current offset of s and its end.
+func remaining(s io.Seeker) (int64, error) {
+	cur, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := s.Seek(cur, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return end - cur, nil
+}
+
+// readNpy reads a Tensor in NumPy's npy format from the given reader,
+// which holds limit bytes, or an unknown number of bytes if limit is -1.
+func readNpy[T nune.Numeric](r io.Reader, limit int64) (*Tensor[T], error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, npyReadError(err)
+	}
+
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, fmt.Errorf("%w: bad magic string", ErrBadNpy)
+	}
+
+	var size, lenSize int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, npyReadError(err)
+		}
+		size, lenSize = int(n), 2
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, npyReadError(err)
+		}
+		size, lenSize = int(n), 4
+	default:
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrBadNpy, major)
+	}
+
+	if size > npyMaxHeader || limit >= 0 && int64(len(prefix)+lenSize+size) > limit {
+		return nil, fmt.Errorf("%w: header of %d bytes is too large", ErrBadNpy, size)
+	}
+
+	header := make([]byte, size)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, npyReadError(err)
+	}
+
+	descr, fortran, shape, err := parseNpyHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	width, decode, err := npyDecoder[T](descr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the number of elements is bounded as it is computed,
+	// such that the size of the data can't overflow
+	n := 1
+	for _, d := range shape {
+		if d > math.MaxInt/width/n {
+			return nil, fmt.Errorf("%w: shape %v is too large", ErrBadNpy, shape)
+		}
+		n *= d
+	}
+
+	if limit >= 0 && int64(n*width) > limit-int64(len(prefix)+lenSize+size) {
+		return nil, fmt.Errorf("%w: shape %v exceeds the data", ErrBadNpy, shape)
+	}
+
+	// the data is read in chunks, such that the Tensor
+	// only grows as large as the data actually read
+	per := npyChunk / width
+	if per > n {
+		per = n
+	}
+
+	buf := make([]byte, per*width)
+	data := slice.WithCap[T](per)
+
+	for len(data) < n {
+		m := n - len(data)
+		if m > per {
+			m = per
+		}
+
+		b := buf[:m*width]
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, npyReadError(err)
+		}
+
+		for i := 0; i < m; i++ {
+			data = append(data, decode(b[i*width:]))
+		}
+	}
+
+	if !fortran {
+		return FromBuffer(data, shape...), nil
+	}
+
+	// Fortran order is the row-major order of the reversed shape
+	rev := slice.WithLen[int](len(shape))
+	for i, d := range shape {
+		rev[len(shape)-1-i] = d
+	}
+
+	return FromBuffer(data, rev...).Transpose().Copy(), nil
+}
+
+// WriteNpy writes the Tensor in NumPy's npy format to the given writer,
+// in little-endian byte order. Tensors of types of platform-dependent
+// size, such as int, are written with 64-bit dtypes.
+func WriteNpy[T nune.Numeric](w io.Writer, t *Tensor[T]) error {
+	descr, width, encode := npyEncoder[T]()
+
+	var shape strings.Builder
+	shape.WriteByte('(')
+	for i, d := range t.layout.Shape() {
+		if i > 0 {
+			shape.WriteString(", ")
+		}
+		shape.WriteString(strconv.Itoa(d))
+	}
+	if t.Rank() == 1 {
+		shape.WriteByte(',')
+	}
+	shape.WriteByte(')')
+
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': %s, }", descr, shape.String())
+
+	// version 1.0 stores the header's length on 2 bytes, 2.0 on 4 bytes
+	version, lenSize := byte(1), 2
+	if len(header)+npyAlign > math.MaxUint16 {
+		version, lenSize = 2, 4
+	}
+
+	// the header is padded with spaces and ends with a newline
+	total := len(npyMagic) + 2 + lenSize + len(header) + 1
+	header += strings.Repeat(" ", (npyAlign-total%npyAlign)%npyAlign) + "\n"
+
+	var prefix bytes.Buffer
+	prefix.WriteString(npyMagic)
+	prefix.Write([]byte{version, 0})
+	if lenSize == 2 {
+		binary.Write(&prefix, binary.LittleEndian, uint16(len(header)))
+	} else {
+		binary.Write(&prefix, binary.LittleEndian, uint32(len(header)))
+	}
+	prefix.WriteString(header)
+
+	if _, err := w.Write(prefix.Bytes()); err != nil {
+		return err
+	}
+
+	data := t.flat()
+
+	buf := make([]byte, len(data)*width)
+	for i, x := range data {
+		encode(buf[i*width:], x)
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// npyReadError returns the error of a read from an npy file,
+// which is malformed if it ends prematurely.
+func npyReadError(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: truncated file", ErrBadNpy)
+	}
+
+	return err
+}
+
+// parseNpyHeader parses the dictionary literal of an npy header,
+// and returns its dtype descriptor, Fortran order and shape.
+func parseNpyHeader(header string) (descr string, fortran bool, shape []int, err error) {
+	bad := fmt.Errorf("%w: bad header %q", ErrBadNpy, header)
+
+	h := strings.TrimSpace(header)
+	if !strings.HasPrefix(h, "{") || !strings.HasSuffix(h, "}") {
+		return "", false, nil, bad
+	}
+	h = h[1 : len(h)-1]
+
+	var seen int
+	for {
+		h = strings.TrimLeft(h, " ,")
+		if h == "" {
+			break
+		}
+
+		key, rest, ok := npyString(h)
+		if !ok {
+			return "", false, nil, bad
+		}
+		rest = strings.TrimLeft(rest, " ")
+		if !strings.HasPrefix(rest, ":") {
+			return "", false, nil, bad
+		}
+		rest = strings.TrimLeft(rest[1:], " ")
+
+		switch key {
+		case "descr":
+			descr, h, ok = npyString(rest)
+		case "fortran_order":
+			switch {
+			case strings.HasPrefix(rest, "True"):
+				fortran, h = true, rest[4:]
+			case strings.HasPrefix(rest, "False"):
+				fortran, h = false, rest[5:]
+			default:
+				ok = false
+			}
+		case "shape":
+			end := strings.IndexByte(rest, ')')
+			if !strings.HasPrefix(rest, "(") || end < 0 {
+				return "", false, nil, bad
+			}
+
+			shape = []int{}
+			for _, f := range strings.Split(rest[1:end], ",") {
+				if f = strings.TrimSpace(f); f == "" {
+					continue
+				}
+
+				d, err := strconv.Atoi(strings.TrimSuffix(f, "L"))
+				if err != nil || d < 0 {
+					return "", false, nil, bad
+				}
+				shape = append(shape, d)
+			}
+
+			h = rest[end+1:]
+		default:
+			ok = false
+		}
+
+		if !ok {
+			return "", false, nil, bad
+		}
+		seen++
+	}
+
+	if seen != 3 || descr == "" || shape == nil {
+		return "", false, nil, bad
+	}
+
+	for _, d := range shape {
+		if d == 0 {
+			return "", false, nil, fmt.Errorf("%w: empty arrays are not supported", ErrBadNpy)
+		}
+	}
+
+	return descr, fortran, shape, nil
+}
+
+// npyString parses the quoted string opening s,
+// and returns it along with the rest of s.
+func npyString(s string) (str, rest string, ok bool) {
+	if len(s) == 0 || s[0] != '\'' && s[0] != '"' {
+		return "", s, false
+	}
+
+	end := strings.IndexByte(s[1:], s[0])
+	if end < 0 {
+		return "", s, false
+	}
+
+	return s[1 : end+1], s[end+2:], true
+}
+
+// npyDecoder returns the width of the elements of the given dtype,
+// and a function decoding an element into the type T.
+func npyDecoder[T nune.Numeric](descr string) (int, func([]byte) T, error) {
+	bad := fmt.Errorf("%w: unsupported dtype %q", ErrBadNpy, descr)
+
+	if len(descr) < 3 {
+		return 0, nil, bad
+	}
+
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	case '=':
+		order = nativeEndian
+	default:
+		return 0, nil, bad
+	}
+
+	width, err := strconv.Atoi(descr[2:])
+	if err != nil {
+		return 0, nil, bad
+	}
+
+	var decode func([]byte) T
+	switch kind := descr[1]; {
+	case kind == 'b' && width == 1, kind == 'u' && width == 1:
+		decode = func(b []byte) T { return T(b[0]) }
+	case kind == 'i' && width == 1:
+		decode = func(b []byte) T { return T(int8(b[0])) }
+	case kind == 'u' && width == 2:
+		decode = func(b []byte) T { return T(order.Uint16(b)) }
+	case kind == 'i' && width == 2:
+		decode = func(b []byte) T { return T(int16(order.Uint16(b))) }
+	case kind == 'u' && width == 4:
+		decode = func(b []byte) T { return T(order.Uint32(b)) }
+	case kind == 'i' && width == 4:
+		decode = func(b []byte) T { return T(int32(order.Uint32(b))) }
+	case kind == 'u' && width == 8:
+		decode = func(b []byte) T { return T(order.Uint64(b)) }
+	case kind == 'i' && width == 8:
+		decode = func(b []byte) T { return T(int64(order.Uint64(b))) }
+	case kind == 'f' && width == 4:
+		decode = func(b []byte) T { return T(math.Float32frombits(order.Uint32(b))) }
+	case kind == 'f' && width == 8:
+		decode = func(b []byte) T { return T(math.Float64frombits(order.Uint64(b))) }
+	default:
+		return 0, nil, bad
+	}
+
+	return width, decode, nil
+}
+
+// npyEncoder returns the little-endian dtype descriptor matching the type T,
+// the width of its elements and a function encoding an element.
+func npyEncoder[T nune.Numeric]() (string, int, func([]byte, T)) {
+	le := binary.LittleEndian
+
+	switch reflect.TypeOf(T(0)).Kind() {
+	case reflect.Int8:
+		return "|i1", 1, func(b []byte, x T) { b[0] = byte(x) }
+	case reflect.Uint8:
+		return "|u1", 1, func(b []byte, x T) { b[0] = byte(x) }
+	case reflect.Int16:
+		return "<i2", 2, func(b []byte, x T) { le.PutUint16(b, uint16(x)) }
+	case reflect.Uint16:
+		return "<u2", 2, func(b []byte, x T) { le.PutUint16(b, uint16(x)) }
+	case reflect.Int32:
+		return "<i4", 4, func(b []byte, x T) { le.PutUint32(b, uint32(x)) }
+	case reflect.Uint32:
+		return "<u4", 4, func(b []byte, x T) { le.PutUint32(b, uint32(x)) }
+	case reflect.Int, reflect.Int64:
+		return "<i8", 8, func(b []byte, x T) { le.PutUint64(b, uint64(x)) }
+	case reflect.Uint, reflect.Uint64:
+		return "<u8", 8, func(b []byte, x T) { le.PutUint64(b, uint64(x)) }
+	case reflect.Float32:
+		return "<f4", 4, func(b []byte, x T) { le.PutUint32(b, math.Float32bits(float32(x))) }
+	default:
+		return "<f8", 8, func(b []byte, x T) { le.PutUint64(b, math.Float64bits(float64(x))) }
+	}
+}
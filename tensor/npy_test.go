@@ -0,0 +1,169 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+// rawNpy returns a version 1.0 npy file made of the given header and data.
+func rawNpy(header string, data []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(npyMagic)
+	b.Write([]byte{1, 0})
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)))
+	b.WriteString(header)
+	b.Write(data)
+
+	return b.Bytes()
+}
+
+// onlyReader hides every method of a reader but Read.
+type onlyReader struct {
+	r io.Reader
+}
+
+func (o onlyReader) Read(p []byte) (int, error) {
+	return o.r.Read(p)
+}
+
+func TestNpyRoundTrip(t *testing.T) {
+	x := Range[float32](0, 24, 1).Reshape(2, 3, 4)
+
+	var b bytes.Buffer
+	if err := WriteNpy(&b, x.Permute(2, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	y, err := ReadNpy[float32](onlyReader{&b})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !equal(y, x.Permute(2, 0, 1)) {
+		t.Errorf("got %v, want %v", y, x.Permute(2, 0, 1))
+	}
+}
+
+func TestReadNpyFortran(t *testing.T) {
+	data := make([]byte, 6*8)
+	for i := 0; i < 6; i++ {
+		binary.BigEndian.PutUint64(data[i*8:], uint64(i))
+	}
+
+	raw := rawNpy("{'descr': '>i8', 'fortran_order': True, 'shape': (2, 3), }\n", data)
+
+	x, err := ReadNpy[int](bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := FromBuffer([]int{0, 2, 4, 1, 3, 5}, 2, 3)
+	if !equal(x, want) {
+		t.Errorf("got %v, want %v", x, want)
+	}
+}
+
+// hugeHeader is a version 2.0 npy file claiming a header of almost 4 GiB.
+var hugeHeader = append([]byte("\x93NUMPY\x02\x00\xf0\xff\xff\xff"), make([]byte, 60)...)
+
+func TestReadNpyMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{"bad magic", []byte("\x93NUMPX\x01\x00\x00\x00"), ErrBadNpy},
+		{"bad version", []byte("\x93NUMPY\x07\x00\x00\x00"), ErrBadNpy},
+		{"truncated prefix", []byte("\x93NUM"), ErrBadNpy},
+		{"truncated header", rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }", nil)[:20], ErrBadNpy},
+		{"huge header", hugeHeader, ErrBadNpy},
+		{"bad header", rawNpy("{'descr': '<f8'", nil), ErrBadNpy},
+		{"missing key", rawNpy("{'descr': '<f8', 'shape': (2,), }", nil), ErrBadNpy},
+		{"unknown dtype", rawNpy("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }", make([]byte, 16)), ErrBadNpy},
+		{"empty array", rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (0,), }", nil), ErrBadNpy},
+		{"negative dimension", rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (-2,), }", nil), ErrBadNpy},
+		{"overflowing shape", rawNpy("{'descr': '<f4', 'fortran_order': False, 'shape': (4611686018427387904, 4), }", nil), ErrBadNpy},
+		{"overflowing data", rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (1152921504606846976, 3), }", nil), ErrBadNpy},
+		{"shape exceeding data", rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (1000000, 1000000), }", make([]byte, 64)), ErrBadNpy},
+		{"truncated data", rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (4,), }", make([]byte, 24)), ErrBadNpy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadNpy[float64](bytes.NewReader(tt.raw)); !errors.Is(err, tt.err) {
+				t.Errorf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestReadNpyUnseekable(t *testing.T) {
+	// without knowing the size of the data, a large shape must
+	// only fail once the data runs out, without allocating it first
+	raw := rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (1000000, 1000000), }", make([]byte, 64))
+
+	if _, err := ReadNpy[float64](onlyReader{bytes.NewReader(raw)}); !errors.Is(err, ErrBadNpy) {
+		t.Errorf("got %v, want %v", err, ErrBadNpy)
+	}
+
+	if _, err := ReadNpy[float64](onlyReader{bytes.NewReader(hugeHeader)}); !errors.Is(err, ErrBadNpy) {
+		t.Errorf("huge header: got %v, want %v", err, ErrBadNpy)
+	}
+
+	raw = rawNpy("{'descr': '<f4', 'fortran_order': False, 'shape': (4611686018427387904, 4), }", nil)
+	if _, err := ReadNpy[float64](onlyReader{bytes.NewReader(raw)}); !errors.Is(err, ErrBadNpy) {
+		t.Errorf("got %v, want %v", err, ErrBadNpy)
+	}
+}
+
+func TestNpzRoundTrip(t *testing.T) {
+	tensors := map[string]*Tensor[int]{
+		"a": Range[int](0, 6, 1).Reshape(2, 3),
+		"b": From[int](7),
+	}
+
+	for _, compress := range []bool{false, true} {
+		var b bytes.Buffer
+		if err := WriteNpz(&b, tensors, compress); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ReadNpz[int](bytes.NewReader(b.Bytes()), int64(b.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for name, want := range tensors {
+			if !equal(got[name], want) {
+				t.Errorf("%s (compress: %v): got %v, want %v", name, compress, got[name], want)
+			}
+		}
+	}
+}
+
+func TestReadNpzMalformed(t *testing.T) {
+	var b bytes.Buffer
+	z := zip.NewWriter(&b)
+	f, err := z.Create("x.npy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(rawNpy("{'descr': '<f8', 'fortran_order': False, 'shape': (1000000, 1000000), }", make([]byte, 64)))
+	z.Close()
+
+	if _, err := ReadNpz[float64](bytes.NewReader(b.Bytes()), int64(b.Len())); !errors.Is(err, ErrBadNpy) {
+		t.Errorf("got %v, want %v", err, ErrBadNpy)
+	}
+
+	if _, err := ReadNpz[float64](bytes.NewReader([]byte("not a zip")), 9); !errors.Is(err, ErrBadNpy) {
+		t.Errorf("got %v, want %v", err, ErrBadNpy)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lordlarker/nune"
+)
+
+// ReadNpz reads the Tensors of an archive in NumPy's npz format,
+// of the given size, from the given reader, and returns them by name.
+// Both stored and deflated archives are supported, and the elements
+// of the Tensors are converted to T as by ReadNpy.
+func ReadNpz[T nune.Numeric](r io.ReaderAt, size int64) (map[string]*Tensor[T], error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadNpy, err)
+	}
+
+	tensors := make(map[string]*Tensor[T], len(z.File))
+	for _, f := range z.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+
+		t, err := readNpzFile[T](f)
+		if err != nil {
+			return nil, fmt.Errorf("%w (in %s)", err, f.Name)
+		}
+
+		tensors[name] = t
+	}
+
+	return tensors, nil
+}
+
+// WriteNpz writes the given Tensors, by name, in NumPy's npz format to
+// the given writer, as a deflated archive if compress is true, or as a
+// stored one otherwise.
+func WriteNpz[T nune.Numeric](w io.Writer, tensors map[string]*Tensor[T], compress bool) error {
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	method := zip.Store
+	if compress {
+		method = zip.Deflate
+	}
+
+	z := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := z.CreateHeader(&zip.FileHeader{
+			Name:   name + ".npy",
+			Method: method,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := WriteNpy(f, tensors[name]); err != nil {
+			return err
+		}
+	}
+
+	return z.Close()
+}
+
+// readNpzFile reads the Tensor held by a file of an npz archive.
+func readNpzFile[T nune.Numeric](f *zip.File) (*Tensor[T], error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadNpy, err)
+	}
+	defer rc.Close()
+
+	limit := int64(-1)
+	if f.UncompressedSize64 <= math.MaxInt64 {
+		limit = int64(f.UncompressedSize64)
+	}
+
+	t, err := readNpy[T](rc, limit)
+	if err != nil && !errors.Is(err, ErrBadNpy) {
+		// corrupted compressed data or checksums
+		return nil, fmt.Errorf("%w: %v", ErrBadNpy, err)
+	}
+
+	return t, err
+}
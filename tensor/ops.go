@@ -5,70 +5,106 @@
 package tensor
 
 import (
+	"github.com/lordlarker/nune"
 	"github.com/lordlarker/nune/internal/cpd"
 	"github.com/lordlarker/nune/internal/slice"
 )
 
-// Add takes a Tensor and performs element-wise addition,
-// by reference, over the two Tensor's elements, and then
-// returns the resulting Tensor.
-func (t *Tensor[T]) Add(other *Tensor[T]) *Tensor[T] {
-	if !slice.Equal(t.Shape(), other.Shape()) {
-		panic("nune/tensor: Tensor.Add received a Tensor with a different shape than its own")
-	}
+// Add performs element-wise addition over the elements of the
+// two Tensors, broadcasted together, and returns the results
+// in a new Tensor, or in out if it is provided.
+func Add[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	res := result("Add", BroadcastShapes(a.layout.Shape(), b.layout.Shape()), out)
+	broadcastOp(a, b, res, add[T])
 
-	cpd.Op(t.storage.Load(), other.storage.Load(), t.storage.Load(), func(t1, t2 T) T {
-		return t1 + t2
-	})
+	return res
+}
 
-	return t
+// AddInPlace performs element-wise addition, by reference,
+// over the two Tensor's elements, and then returns the Tensor.
+// The other Tensor is broadcasted to the Tensor's shape.
+func (t *Tensor[T]) AddInPlace(other *Tensor[T]) *Tensor[T] {
+	return Add(t, other, t)
 }
 
-// Sub takes a Tensor and performs element-wise subtraction,
-// by reference, over the two Tensor's elements, and then
-// returns the resulting Tensor.
-func (t *Tensor[T]) Sub(other *Tensor[T]) *Tensor[T] {
-	if !slice.Equal(t.Shape(), other.Shape()) {
-		panic("nune/tensor: Tensor.Sub received a Tensor with a different shape than its own")
-	}
+// Sub performs element-wise subtraction over the elements of the
+// two Tensors, broadcasted together, and returns the results
+// in a new Tensor, or in out if it is provided.
+func Sub[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	res := result("Sub", BroadcastShapes(a.layout.Shape(), b.layout.Shape()), out)
+	broadcastOp(a, b, res, sub[T])
 
-	cpd.Op(t.storage.Load(), other.storage.Load(), t.storage.Load(), func(t1, t2 T) T {
-		return t1 - t2
-	})
+	return res
+}
 
-	return t
+// SubInPlace performs element-wise subtraction, by reference,
+// over the two Tensor's elements, and then returns the Tensor.
+// The other Tensor is broadcasted to the Tensor's shape.
+func (t *Tensor[T]) SubInPlace(other *Tensor[T]) *Tensor[T] {
+	return Sub(t, other, t)
 }
 
-// Mul takes a Tensor and performs element-wise multiplication,
-// by reference, over the two Tensor's elements, and then
-// returns the resulting Tensor.
-func (t *Tensor[T]) Mul(other *Tensor[T]) *Tensor[T] {
-	if !slice.Equal(t.Shape(), other.Shape()) {
-		panic("nune/tensor: Tensor.Mul received a Tensor with a different shape than its own")
-	}
+// Mul performs element-wise multiplication over the elements of the
+// two Tensors, broadcasted together, and returns the results
+// in a new Tensor, or in out if it is provided.
+func Mul[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	res := result("Mul", BroadcastShapes(a.layout.Shape(), b.layout.Shape()), out)
+	broadcastOp(a, b, res, mul[T])
 
-	cpd.Op(t.storage.Load(), other.storage.Load(), t.storage.Load(), func(t1, t2 T) T {
-		return t1 * t2
-	})
+	return res
+}
 
-	return t
+// MulInPlace performs element-wise multiplication, by reference,
+// over the two Tensor's elements, and then returns the Tensor.
+// The other Tensor is broadcasted to the Tensor's shape.
+func (t *Tensor[T]) MulInPlace(other *Tensor[T]) *Tensor[T] {
+	return Mul(t, other, t)
 }
 
-// Div takes a Tensor and performs element-wise division,
-// by reference, over the two Tensor's elements, and then
-// returns the resulting Tensor.
-func (t *Tensor[T]) Div(other *Tensor[T]) *Tensor[T] {
-	if !slice.Equal(t.Shape(), other.Shape()) {
-		panic("nune/tensor: Tensor.Div received a Tensor with a different shape than its own")
-	}
+// Div performs element-wise division over the elements of the
+// two Tensors, broadcasted together, and returns the results
+// in a new Tensor, or in out if it is provided.
+// It panics with ErrDivisionByZero if b holds a zero.
+func Div[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	assertNonZero(b)
+
+	res := result("Div", BroadcastShapes(a.layout.Shape(), b.layout.Shape()), out)
+	broadcastOp(a, b, res, div[T])
+
+	return res
+}
 
-	cpd.Op(t.storage.Load(), other.storage.Load(), t.storage.Load(), func(t1, t2 T) T {
-		if t2 == T(0) {
-			panic("nune/tensor: division by zero occurred in Tensor.Div")
-		}
+// DivInPlace performs element-wise division, by reference,
+// over the two Tensor's elements, and then returns the Tensor.
+// The other Tensor is broadcasted to the Tensor's shape.
+func (t *Tensor[T]) DivInPlace(other *Tensor[T]) *Tensor[T] {
+	return Div(t, other, t)
+}
+
+func add[T nune.Numeric](x, y T) T {
+	return x + y
+}
+
+func sub[T nune.Numeric](x, y T) T {
+	return x - y
+}
 
-		return t1 / t2
-	})
+func mul[T nune.Numeric](x, y T) T {
+	return x * y
+}
+
+func div[T nune.Numeric](x, y T) T {
+	return x / y
+}
+
+// broadcastOp applies f element-wise over the Tensors a and b
+// broadcasted together, and writes the results into res,
+// which must have the broadcasted shape.
+func broadcastOp[T nune.Numeric](a, b, res *Tensor[T], f func(T, T) T) {
+	shape := BroadcastShapes(a.layout.Shape(), b.layout.Shape())
+	if !slice.Equal(shape, res.layout.Shape()) {
+		panic(ErrBroadcast)
+	}
 
-	return t
+	cpd.Op(shape, a.BroadcastTo(shape...).span(), b.BroadcastTo(shape...).span(), res.span(), f)
 }
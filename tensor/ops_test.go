@@ -0,0 +1,139 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBroadcastedOps(t *testing.T) {
+	col := FromBuffer([]float64{1, 2}, 2, 1)
+	row := FromBuffer([]float64{10, 20, 40}, 3)
+
+	tests := []struct {
+		name string
+		op   func(a, b *Tensor[float64], out ...*Tensor[float64]) (*Tensor[float64], error)
+		a, b *Tensor[float64]
+		want *Tensor[float64]
+	}{
+		{"add", TryAdd[float64], col, row, FromBuffer([]float64{11, 21, 41, 12, 22, 42}, 2, 3)},
+		{"sub", TrySub[float64], row, col, FromBuffer([]float64{9, 19, 39, 8, 18, 38}, 2, 3)},
+		{"mul", TryMul[float64], col, row, FromBuffer([]float64{10, 20, 40, 20, 40, 80}, 2, 3)},
+		{"div", TryDiv[float64], row, col, FromBuffer([]float64{10, 20, 40, 5, 10, 20}, 2, 3)},
+		{"scalar", TryMul[float64], From[float64](2), col, FromBuffer([]float64{2, 4}, 2, 1)},
+		{"transposed", TryAdd[float64], col.Transpose(), col, FromBuffer([]float64{2, 3, 3, 4}, 2, 2)},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.op(tt.a, tt.b)
+		if err != nil || !equal(got, tt.want) {
+			t.Errorf("%s: got %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := TryAdd(row, FromBuffer([]float64{1, 2}, 2)); !errors.Is(err, ErrBroadcast) {
+		t.Errorf("mismatch: got %v, want %v", err, ErrBroadcast)
+	}
+}
+
+func TestOut(t *testing.T) {
+	a := FromBuffer([]float64{1, 2, 3, 4}, 2, 2)
+	b := FromBuffer([]float64{4, 3, 2, 1}, 2, 2)
+
+	tests := []struct {
+		name string
+		op   func(a, b *Tensor[float64], out ...*Tensor[float64]) *Tensor[float64]
+		want []float64
+	}{
+		{"add", Add[float64], []float64{5, 5, 5, 5}},
+		{"sub", Sub[float64], []float64{-3, -1, 1, 3}},
+		{"mul", Mul[float64], []float64{4, 6, 6, 4}},
+		{"div", Div[float64], []float64{0.25, 2.0 / 3, 1.5, 4}},
+	}
+
+	for _, tt := range tests {
+		out := Zeros[float64](2, 2)
+		want := FromBuffer(tt.want, 2, 2)
+
+		if got := tt.op(a, b, out); got != out || !equal(out, want) {
+			t.Errorf("%s: got %v in out %v, want %v", tt.name, got, out, want)
+		}
+
+		if got := tt.op(a, b); got == a || got == b || !equal(got, want) {
+			t.Errorf("%s: got %v, want %v in a new Tensor", tt.name, got, want)
+		}
+
+		if !equal(a, FromBuffer([]float64{1, 2, 3, 4}, 2, 2)) || !equal(b, FromBuffer([]float64{4, 3, 2, 1}, 2, 2)) {
+			t.Fatalf("%s: operands modified to %v and %v", tt.name, a, b)
+		}
+	}
+
+	// out may be a strided view
+	out := Zeros[float64](2, 2)
+	Add(a, b, out.Transpose())
+	if want := FromBuffer([]float64{5, 5, 5, 5}, 2, 2); !equal(out, want) {
+		t.Errorf("strided out: got %v, want %v", out, want)
+	}
+}
+
+func TestInPlace(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(t, other *Tensor[float64]) *Tensor[float64]
+		want []float64
+	}{
+		{"add", (*Tensor[float64]).AddInPlace, []float64{11, 22, 13, 24}},
+		{"sub", (*Tensor[float64]).SubInPlace, []float64{-9, -18, -7, -16}},
+		{"mul", (*Tensor[float64]).MulInPlace, []float64{10, 40, 30, 80}},
+		{"div", (*Tensor[float64]).DivInPlace, []float64{0.1, 0.1, 0.3, 0.2}},
+	}
+
+	for _, tt := range tests {
+		x := FromBuffer([]float64{1, 2, 3, 4}, 2, 2)
+		other := FromBuffer([]float64{10, 20}, 2) // broadcasted
+
+		if got := tt.op(x, other); got != x || !near(x, FromBuffer(tt.want, 2, 2), 1e-12) {
+			t.Errorf("%s: got %v, want %v", tt.name, x, tt.want)
+		}
+	}
+
+	// the receiver can't be broadcasted
+	x := FromBuffer([]float64{1, 2}, 2)
+	if _, err := try(func() *Tensor[float64] { return x.AddInPlace(Ones[float64](2, 2)) }); !errors.Is(err, ErrBadShape) {
+		t.Errorf("broadcasted receiver: got %v, want %v", err, ErrBadShape)
+	}
+}
+
+func TestBadOut(t *testing.T) {
+	a := Ones[float64](2, 3)
+
+	tests := []struct {
+		name string
+		out  []*Tensor[float64]
+		err  error
+	}{
+		{"shape", []*Tensor[float64]{Zeros[float64](3, 2)}, ErrBadShape},
+		{"rank", []*Tensor[float64]{Zeros[float64](6)}, ErrBadShape},
+		{"several", []*Tensor[float64]{Zeros[float64](2, 3), Zeros[float64](2, 3)}, ErrArgsBounds},
+	}
+
+	for _, tt := range tests {
+		_, err := TryAdd(a, a, tt.out...)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	_, err := TryMul(a, a, Zeros[float64](3, 2))
+
+	var se *ShapeError
+	if !errors.As(err, &se) || se.Op != "Mul" {
+		t.Fatalf("got %v, want a *ShapeError from Mul", err)
+	}
+	if want := "nune: received a bad shape in Mul: expected shape [2 3], got [3 2]"; se.Error() != want {
+		t.Errorf("got %q, want %q", se.Error(), want)
+	}
+}
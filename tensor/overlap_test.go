@@ -0,0 +1,60 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWriteOverlapping(t *testing.T) {
+	row := Range[float64](0, 3, 1)
+
+	tests := []struct {
+		name  string
+		write func(v *Tensor[float64]) error
+	}{
+		{"out", func(v *Tensor[float64]) error {
+			_, err := TryAdd(row, row, v)
+			return err
+		}},
+		{"in place", func(v *Tensor[float64]) error {
+			_, err := try(func() *Tensor[float64] { return v.AddInPlace(row) })
+			return err
+		}},
+		{"assign", func(v *Tensor[float64]) error {
+			_, err := v.TryAssign([][]float64{{1, 2, 3}, {4, 5, 6}})
+			return err
+		}},
+		{"scatter", func(v *Tensor[float64]) error {
+			idx := Zeros[int](1, 3)
+			_, err := try(func() *Tensor[float64] { return v.ScatterInPlace(0, idx, Ones[float64](1, 3)) })
+			return err
+		}},
+		{"masked fill", func(v *Tensor[float64]) error {
+			_, err := try(func() *Tensor[float64] { return v.MaskedFillInPlace(Gt(v, Zeros[float64](2, 3)), 1) })
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, v := range []*Tensor[float64]{row.BroadcastTo(2, 3), row.Unsqueeze(0).Expand(2, -1)} {
+			if err := tt.write(v); !errors.Is(err, ErrOverlap) {
+				t.Errorf("%s: got %v, want %v", tt.name, err, ErrOverlap)
+			}
+		}
+	}
+
+	// a view with a null stride along a unit axis doesn't overlap
+	v := row.Sl(NewAxis)
+	if _, err := TryAdd(row.Sl(NewAxis), row, v); err != nil {
+		t.Errorf("got %v writing to a unit broadcasted axis", err)
+	}
+
+	m := MaskFromBuffer([]bool{true, false, true}, 3).BroadcastTo(2, 3)
+	if _, err := try(func() *Mask { return Not(m, m) }); !errors.Is(err, ErrOverlap) {
+		t.Errorf("mask: got %v, want %v", err, ErrOverlap)
+	}
+}
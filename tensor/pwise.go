@@ -7,143 +7,233 @@ package tensor
 import (
 	"math"
 
+	"github.com/lordlarker/nune"
 	"github.com/lordlarker/nune/internal/cpd"
 )
 
-// PwiseOp performs a pointwise operation
-// over each element of the Tensor.
-func (t *Tensor[T]) PwiseOp(f func(T) T) *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), f)
+// PwiseOp performs a pointwise operation over each element
+// of the Tensor, and returns the results in a new Tensor,
+// or in out if it is provided.
+func PwiseOp[T nune.Numeric](t *Tensor[T], f func(T) T, out ...*Tensor[T]) *Tensor[T] {
+	res := result("PwiseOp", t.layout.Shape(), out)
+	cpd.Pointwise(t.layout.Shape(), t.span(), res.span(), f)
 
-	return t
+	return res
 }
 
-// Abs computes the absolute value of each
+// PwiseOpInPlace performs a pointwise operation,
+// in place, over each element of the Tensor.
+func (t *Tensor[T]) PwiseOpInPlace(f func(T) T) *Tensor[T] {
+	return PwiseOp(t, f, t)
+}
+
+// Abs computes the absolute value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Abs[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, abs[T], out...)
+}
+
+// AbsInPlace computes the absolute value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Abs() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Abs(float64(x)))
-	})
+func (t *Tensor[T]) AbsInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(abs[T])
+}
 
-	return t
+// Sin computes the sine value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Sin[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, sin[T], out...)
 }
 
-// Sin computes the sine value of each
+// SinInPlace computes the sine value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Sin() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Sin(float64(x)))
-	})
+func (t *Tensor[T]) SinInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(sin[T])
+}
 
-	return t
+// Cos computes the cosine value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Cos[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, cos[T], out...)
 }
 
-// Cos computes the cosine value of each
+// CosInPlace computes the cosine value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Cos() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Cos(float64(x)))
-	})
+func (t *Tensor[T]) CosInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(cos[T])
+}
 
-	return t
+// Tan computes the tan value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Tan[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, tan[T], out...)
 }
 
-// Tan computes the tan value of each
+// TanInPlace computes the tan value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Tan() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Tan(float64(x)))
-	})
+func (t *Tensor[T]) TanInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(tan[T])
+}
 
-	return t
+// Log computes the natural log value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Log[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, log[T], out...)
 }
 
-// Log computes the natural log value of each
+// LogInPlace computes the natural log value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Log() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Log(float64(x)))
-	})
+func (t *Tensor[T]) LogInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(log[T])
+}
 
-	return t
+// Log2 computes the binary log value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Log2[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, log2[T], out...)
 }
 
-// Log2 computes the binary log value of each
+// Log2InPlace computes the binary log value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Log2() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Log2(float64(x)))
-	})
+func (t *Tensor[T]) Log2InPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(log2[T])
+}
 
-	return t
+// Log10 computes the decimal log value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Log10[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, log10[T], out...)
 }
 
-// Log10 computes the decimal log value of each
+// Log10InPlace computes the decimal log value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Log10() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Log10(float64(x)))
-	})
+func (t *Tensor[T]) Log10InPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(log10[T])
+}
 
-	return t
+// Exp computes the base-e exponential value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Exp[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, exp[T], out...)
 }
 
-// Exp computes the base-e exponential value of each
+// ExpInPlace computes the base-e exponential value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Exp() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Exp(float64(x)))
-	})
+func (t *Tensor[T]) ExpInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(exp[T])
+}
 
-	return t
+// Pow computes the base-value exponential of p of each element
+// of the Tensor, and returns the results in a new Tensor,
+// or in out if it is provided.
+func Pow[T nune.Numeric](t *Tensor[T], p T, out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, pow(p), out...)
 }
 
-// Pow computes the base-value exponential of p of each
+// PowInPlace computes the base-value exponential of p of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Pow(p T) *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Pow(float64(x), float64(p)))
-	})
+func (t *Tensor[T]) PowInPlace(p T) *Tensor[T] {
+	return t.PwiseOpInPlace(pow(p))
+}
 
-	return t
+// Sqrt computes the square root value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Sqrt[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, sqrt[T], out...)
 }
 
-// Sqrt computes the square root value of each
+// SqrtInPlace computes the square root value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Sqrt() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Sqrt(float64(x)))
-	})
+func (t *Tensor[T]) SqrtInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(sqrt[T])
+}
 
-	return t
+// Round computes the nearest integer value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Round[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, round[T], out...)
 }
 
-// Round computes the nearest integer value of each
+// RoundInPlace computes the nearest integer value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Round() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Round(float64(x)))
-	})
+func (t *Tensor[T]) RoundInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(round[T])
+}
 
-	return t
+// Floor computes the nearest lesser integer value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Floor[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, floor[T], out...)
 }
 
-// Floor computes the nearest lesser integer value of each
+// FloorInPlace computes the nearest lesser integer value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Floor() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Floor(float64(x)))
-	})
+func (t *Tensor[T]) FloorInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(floor[T])
+}
 
-	return t
+// Ceil computes the nearest greater value of each element of the Tensor,
+// and returns the results in a new Tensor, or in out if it is provided.
+func Ceil[T nune.Numeric](t *Tensor[T], out ...*Tensor[T]) *Tensor[T] {
+	return PwiseOp(t, ceil[T], out...)
 }
 
-// Ceil computes the nearest greater value of each
+// CeilInPlace computes the nearest greater value of each
 // element of the Tensor and returns the Tensor.
-func (t *Tensor[T]) Ceil() *Tensor[T] {
-	cpd.Pointwise(t.storage.Load(), func(x T) T {
-		return T(math.Ceil(float64(x)))
-	})
+func (t *Tensor[T]) CeilInPlace() *Tensor[T] {
+	return t.PwiseOpInPlace(ceil[T])
+}
+
+func abs[T nune.Numeric](x T) T {
+	return T(math.Abs(float64(x)))
+}
+
+func sin[T nune.Numeric](x T) T {
+	return T(math.Sin(float64(x)))
+}
+
+func cos[T nune.Numeric](x T) T {
+	return T(math.Cos(float64(x)))
+}
+
+func tan[T nune.Numeric](x T) T {
+	return T(math.Tan(float64(x)))
+}
+
+func log[T nune.Numeric](x T) T {
+	return T(math.Log(float64(x)))
+}
+
+func log2[T nune.Numeric](x T) T {
+	return T(math.Log2(float64(x)))
+}
+
+func log10[T nune.Numeric](x T) T {
+	return T(math.Log10(float64(x)))
+}
+
+func exp[T nune.Numeric](x T) T {
+	return T(math.Exp(float64(x)))
+}
+
+func pow[T nune.Numeric](p T) func(T) T {
+	return func(x T) T {
+		return T(math.Pow(float64(x), float64(p)))
+	}
+}
+
+func sqrt[T nune.Numeric](x T) T {
+	return T(math.Sqrt(float64(x)))
+}
+
+func round[T nune.Numeric](x T) T {
+	return T(math.Round(float64(x)))
+}
+
+func floor[T nune.Numeric](x T) T {
+	return T(math.Floor(float64(x)))
+}
 
-	return t
+func ceil[T nune.Numeric](x T) T {
+	return T(math.Ceil(float64(x)))
 }
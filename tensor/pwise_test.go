@@ -0,0 +1,55 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPwiseOut(t *testing.T) {
+	x := FromBuffer([]float64{-4, 1, -9, 16}, 2, 2)
+
+	tests := []struct {
+		name    string
+		op      func(t *Tensor[float64], out ...*Tensor[float64]) *Tensor[float64]
+		inPlace func(t *Tensor[float64]) *Tensor[float64]
+		want    []float64
+	}{
+		{"abs", Abs[float64], (*Tensor[float64]).AbsInPlace, []float64{4, 1, 9, 16}},
+		{"round", Round[float64], (*Tensor[float64]).RoundInPlace, []float64{-4, 1, -9, 16}},
+		{"pow", func(t *Tensor[float64], out ...*Tensor[float64]) *Tensor[float64] {
+			return Pow(t, 2, out...)
+		}, func(t *Tensor[float64]) *Tensor[float64] {
+			return t.PowInPlace(2)
+		}, []float64{16, 1, 81, 256}},
+	}
+
+	for _, tt := range tests {
+		want := FromBuffer(tt.want, 2, 2)
+
+		if got := tt.op(x); got == x || !equal(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+
+		out := Zeros[float64](2, 2)
+		if got := tt.op(x, out); got != out || !equal(out, want) {
+			t.Errorf("%s: got %v in out %v, want %v", tt.name, got, out, want)
+		}
+
+		y := x.Copy()
+		if got := tt.inPlace(y); got != y || !equal(y, want) {
+			t.Errorf("%s: in place: got %v, want %v", tt.name, y, want)
+		}
+	}
+
+	if !equal(x, FromBuffer([]float64{-4, 1, -9, 16}, 2, 2)) {
+		t.Errorf("operand modified to %v", x)
+	}
+
+	if _, err := try(func() *Tensor[float64] { return Sqrt(x, Zeros[float64](4)) }); !errors.Is(err, ErrBadShape) {
+		t.Errorf("bad out: got %v, want %v", err, ErrBadShape)
+	}
+}
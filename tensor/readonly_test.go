@@ -0,0 +1,32 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWriteReadOnly(t *testing.T) {
+	x := FromExternal([]float64{1, 2, 3}, 3)
+
+	if _, err := TryAdd(x, x, x); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("out: got %v, want %v", err, ErrReadOnly)
+	}
+
+	// a write bypassing the Tensor's own guard must
+	// still be reported as an error, not crash
+	_, err := try(func() *Tensor[float64] {
+		x.storage.SetIndex(0, 7)
+		return x
+	})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("storage: got %v, want %v", err, ErrReadOnly)
+	}
+
+	if x.Ravel()[0] != 1 {
+		t.Errorf("got %v, want the data left untouched", x)
+	}
+}
@@ -6,10 +6,14 @@ package tensor
 
 import (
 	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
 )
 
+// ReductOp performs a reduction operation over
+// the elements of the Tensor, which f receives
+// in contiguous chunks.
 func (t *Tensor[T]) ReductOp(f func([]T) T) T {
-	return cpd.Reduct(t.storage.Load(), f)
+	return cpd.Reduct(t.flat(), f)
 }
 
 // Min returns the minimum value of all elements in the Tensor.
@@ -40,13 +44,7 @@ func (t *Tensor[T]) Max() T {
 
 // Mean returns the mean value of all elements in the Tensor.
 func (t *Tensor[T]) Mean() T {
-	return t.ReductOp(func(s []T) T {
-		var sum T
-		for i := 0; i < len(s); i++ {
-			sum += s[i]
-		}
-		return sum / T(len(s))
-	})
+	return t.Sum() / T(t.Numel())
 }
 
 // Sum returns the sum of all elements in the Tensor.
@@ -70,3 +68,144 @@ func (t *Tensor[T]) Prod() T {
 		return prod
 	})
 }
+
+// ReduceAxis reduces the given axis of the Tensor by folding f
+// over its elements, starting from init, and returns the results
+// in a new Tensor whose shape lacks the reduced axis.
+func (t *Tensor[T]) ReduceAxis(axis int, init T, f func(T, T) T) *Tensor[T] {
+	return t.reduceAxes([]int{axis}, false, f, init)
+}
+
+// SumAxis returns the sum of the elements of the Tensor over the
+// given axes, or over all axes if none are given. If keepDims is true,
+// the reduced axes are kept in the resulting shape with a dimension of 1.
+func (t *Tensor[T]) SumAxis(axes []int, keepDims bool) *Tensor[T] {
+	return t.reduceAxes(axes, keepDims, add[T], 0)
+}
+
+// ProdAxis returns the product of the elements of the Tensor over the
+// given axes, or over all axes if none are given. If keepDims is true,
+// the reduced axes are kept in the resulting shape with a dimension of 1.
+func (t *Tensor[T]) ProdAxis(axes []int, keepDims bool) *Tensor[T] {
+	return t.reduceAxes(axes, keepDims, mul[T], 1)
+}
+
+// MeanAxis returns the mean value of the elements of the Tensor over the
+// given axes, or over all axes if none are given. If keepDims is true,
+// the reduced axes are kept in the resulting shape with a dimension of 1.
+func (t *Tensor[T]) MeanAxis(axes []int, keepDims bool) *Tensor[T] {
+	res := t.SumAxis(axes, keepDims)
+	n := T(t.Numel() / res.Numel())
+
+	return res.PwiseOpInPlace(func(x T) T {
+		return x / n
+	})
+}
+
+// MinAxis returns the minimum value of the elements of the Tensor over the
+// given axes, or over all axes if none are given. If keepDims is true,
+// the reduced axes are kept in the resulting shape with a dimension of 1.
+func (t *Tensor[T]) MinAxis(axes []int, keepDims bool) *Tensor[T] {
+	return t.reduceAxes(axes, keepDims, func(x, y T) T {
+		if y < x {
+			return y
+		}
+		return x
+	})
+}
+
+// MaxAxis returns the maximum value of the elements of the Tensor over the
+// given axes, or over all axes if none are given. If keepDims is true,
+// the reduced axes are kept in the resulting shape with a dimension of 1.
+func (t *Tensor[T]) MaxAxis(axes []int, keepDims bool) *Tensor[T] {
+	return t.reduceAxes(axes, keepDims, func(x, y T) T {
+		if y > x {
+			return y
+		}
+		return x
+	})
+}
+
+// ArgMinAxis returns the indices of the minimum values of the elements of
+// the Tensor over the given axes, or over all axes if none are given.
+// When reducing several axes, the indices are row-major positions over
+// the reduced axes. If keepDims is true, the reduced axes are kept
+// in the resulting shape with a dimension of 1.
+func (t *Tensor[T]) ArgMinAxis(axes []int, keepDims bool) *Tensor[int] {
+	return t.argReduceAxes(axes, keepDims, func(x, y T) bool {
+		return x < y
+	})
+}
+
+// ArgMaxAxis returns the indices of the maximum values of the elements of
+// the Tensor over the given axes, or over all axes if none are given.
+// When reducing several axes, the indices are row-major positions over
+// the reduced axes. If keepDims is true, the reduced axes are kept
+// in the resulting shape with a dimension of 1.
+func (t *Tensor[T]) ArgMaxAxis(axes []int, keepDims bool) *Tensor[int] {
+	return t.argReduceAxes(axes, keepDims, func(x, y T) bool {
+		return x > y
+	})
+}
+
+// reduceAxes reduces the given axes of the Tensor by folding f over
+// their elements, starting from init if it is provided.
+func (t *Tensor[T]) reduceAxes(axes []int, keepDims bool, f func(T, T) T, init ...T) *Tensor[T] {
+	p, k, shape := t.reductionView(axes, keepDims)
+
+	res := result[T]("ReduceAxis", p.layout.Shape()[:k], nil)
+	cpd.Reduce(p.layout.Shape()[:k], p.layout.Shape()[k:], p.span(), res.span(), f, init...)
+
+	return res.Reshape(shape...)
+}
+
+// argReduceAxes returns the positions over the given axes of the Tensor
+// of the first elements for which better holds against all others.
+func (t *Tensor[T]) argReduceAxes(axes []int, keepDims bool, better func(T, T) bool) *Tensor[int] {
+	p, k, shape := t.reductionView(axes, keepDims)
+
+	res := result[int]("ArgReduceAxis", p.layout.Shape()[:k], nil)
+	cpd.ArgReduce(p.layout.Shape()[:k], p.layout.Shape()[k:], p.span(), res.span(), better)
+
+	return res.Reshape(shape...)
+}
+
+// reductionView returns a view over the Tensor whose first k axes are
+// the kept ones and whose last axes are the ones to reduce, along with
+// the shape of the reduction's result.
+func (t *Tensor[T]) reductionView(axes []int, keepDims bool) (*Tensor[T], int, []int) {
+	reduced := slice.WithLen[bool](t.Rank())
+	if len(axes) == 0 {
+		for i := range reduced {
+			reduced[i] = true
+		}
+	}
+
+	for _, a := range axes {
+		assertAxisBounds(a, t.Rank())
+		if reduced[a] {
+			panic(ErrBadAxes)
+		}
+		reduced[a] = true
+	}
+
+	perm := slice.WithCap[int](t.Rank())
+	shape := slice.WithCap[int](t.Rank())
+	for i, r := range reduced {
+		if !r {
+			perm = append(perm, i)
+			shape = append(shape, t.Size(i))
+		} else if keepDims {
+			shape = append(shape, 1)
+		}
+	}
+	k := len(perm)
+
+	for i, r := range reduced {
+		if r {
+			perm = append(perm, i)
+		}
+	}
+
+	return t.Permute(perm...), k, shape
+}
@@ -0,0 +1,103 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSumAxis(t *testing.T) {
+	x := Range[int](0, 24, 1).Reshape(2, 3, 4)
+
+	tests := []struct {
+		name     string
+		axes     []int
+		keepDims bool
+		want     *Tensor[int]
+	}{
+		{"first", []int{0}, false, FromBuffer([]int{
+			12, 14, 16, 18,
+			20, 22, 24, 26,
+			28, 30, 32, 34,
+		}, 3, 4)},
+		{"middle", []int{1}, true, FromBuffer([]int{
+			12, 15, 18, 21,
+			48, 51, 54, 57,
+		}, 2, 1, 4)},
+		{"last", []int{2}, false, FromBuffer([]int{6, 22, 38, 54, 70, 86}, 2, 3)},
+		{"several", []int{2, 0}, true, FromBuffer([]int{60, 92, 124}, 1, 3, 1)},
+		{"all", nil, false, From[int](276)},
+		{"all kept", nil, true, FromBuffer([]int{276}, 1, 1, 1)},
+	}
+
+	for _, tt := range tests {
+		if got := x.SumAxis(tt.axes, tt.keepDims); !equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+
+		// a strided view reduces as its copy
+		y := x.Permute(2, 0, 1).Copy().Permute(1, 2, 0)
+		if got := y.SumAxis(tt.axes, tt.keepDims); !equal(got, tt.want) {
+			t.Errorf("%s: strided: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestArgMaxAxis(t *testing.T) {
+	x := FromBuffer([]int{
+		3, 9, 2,
+		7, 1, 9,
+
+		4, 4, 0,
+		8, 5, 6,
+	}, 2, 2, 3)
+
+	tests := []struct {
+		name     string
+		axes     []int
+		keepDims bool
+		want     *Tensor[int]
+	}{
+		{"last", []int{2}, false, FromBuffer([]int{1, 2, 0, 0}, 2, 2)},
+		{"last kept", []int{2}, true, FromBuffer([]int{1, 2, 0, 0}, 2, 2, 1)},
+		{"first", []int{0}, true, FromBuffer([]int{1, 0, 0, 1, 1, 0}, 1, 2, 3)},
+		{"several", []int{1, 2}, false, FromBuffer([]int{1, 3}, 2)},
+		{"all", nil, true, FromBuffer([]int{1}, 1, 1, 1)},
+	}
+
+	for _, tt := range tests {
+		if got := x.ArgMaxAxis(tt.axes, tt.keepDims); !equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got, want := x.ArgMinAxis([]int{2}, false), FromBuffer([]int{2, 1, 2, 1}, 2, 2); !equal(got, want) {
+		t.Errorf("ArgMinAxis: got %v, want %v", got, want)
+	}
+}
+
+func TestReduceBadAxes(t *testing.T) {
+	x := Ones[int](2, 3)
+
+	tests := []struct {
+		name string
+		axes []int
+		err  error
+	}{
+		{"out of bounds", []int{2}, ErrAxisBounds},
+		{"negative", []int{-1}, ErrAxisBounds},
+		{"duplicate", []int{1, 1}, ErrBadAxes},
+	}
+
+	for _, tt := range tests {
+		if _, err := try(func() *Tensor[int] { return x.SumAxis(tt.axes, false) }); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+		if _, err := try(func() *Tensor[int] { return x.ArgMaxAxis(tt.axes, true) }); !errors.Is(err, tt.err) {
+			t.Errorf("%s: arg: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune/internal/cpd"
+)
+
+// Scan returns the inclusive scan of the Tensor along the given axis
+// under f, in a new Tensor: each element is the fold of f over the
+// elements before it along the axis, itself included. Since long axes
+// are scanned in concurrent chunks, f must be associative.
+func (t *Tensor[T]) Scan(axis int, f func(T, T) T) *Tensor[T] {
+	assertAxisBounds(axis, t.Rank())
+
+	res := Zeros[T](t.layout.Shape()...)
+	cpd.Scan(t.layout.Shape(), axis, t.span(), res.span(), f)
+
+	return res
+}
+
+// CumSum returns the cumulative sum of the
+// Tensor's elements along the given axis.
+func (t *Tensor[T]) CumSum(axis int) *Tensor[T] {
+	return t.Scan(axis, func(x, y T) T {
+		return x + y
+	})
+}
+
+// CumProd returns the cumulative product of the
+// Tensor's elements along the given axis.
+func (t *Tensor[T]) CumProd(axis int) *Tensor[T] {
+	return t.Scan(axis, func(x, y T) T {
+		return x * y
+	})
+}
+
+// CumMin returns the cumulative minimum of the Tensor's
+// elements along the given axis. NaNs are propagated.
+func (t *Tensor[T]) CumMin(axis int) *Tensor[T] {
+	return t.Scan(axis, func(x, y T) T {
+		if x == x && (y < x || y != y) {
+			return y
+		}
+		return x
+	})
+}
+
+// CumMax returns the cumulative maximum of the Tensor's
+// elements along the given axis. NaNs are propagated.
+func (t *Tensor[T]) CumMax(axis int) *Tensor[T] {
+	return t.Scan(axis, func(x, y T) T {
+		if x == x && (y > x || y != y) {
+			return y
+		}
+		return x
+	})
+}
+
+// LogCumSumExp returns the logarithm of the cumulative sum of the
+// exponentials of the Tensor's elements along the given axis, computed
+// such that it doesn't overflow for large elements.
+func (t *Tensor[T]) LogCumSumExp(axis int) *Tensor[T] {
+	return t.Scan(axis, func(x, y T) T {
+		a, b := float64(x), float64(y)
+		if a < b {
+			a, b = b, a
+		}
+
+		if math.IsInf(a, 0) {
+			return T(a)
+		}
+		return T(a + math.Log1p(math.Exp(b-a)))
+	})
+}
+
+// Diff returns the n-th discrete difference of the Tensor along the
+// given axis, in a new Tensor whose dimension at that axis is reduced
+// by n. The first difference is given by res[i] = t[i+1] - t[i] along
+// the axis, and higher ones are found by applying it repeatedly.
+func (t *Tensor[T]) Diff(n, axis int) *Tensor[T] {
+	assertAxisBounds(axis, t.Rank())
+	assertInRange(n, 0, t.Size(axis))
+
+	if n == 0 {
+		return t.Copy()
+	}
+
+	res := t
+	for i := 0; i < n; i++ {
+		d := res.Size(axis)
+		res = Sub(res.SliceAxis(axis, 1, d, 1), res.SliceAxis(axis, 0, d-1, 1))
+	}
+
+	return res
+}
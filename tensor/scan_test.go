@@ -0,0 +1,88 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	x := From[float64]([][]float64{{1, 3, 2}, {-1, 4, -2}})
+
+	tests := []struct {
+		name string
+		got  *Tensor[float64]
+		want [][]float64
+	}{
+		{"cumsum", x.CumSum(1), [][]float64{{1, 4, 6}, {-1, 3, 1}}},
+		{"cumsum first axis", x.CumSum(0), [][]float64{{1, 3, 2}, {0, 7, 0}}},
+		{"cumprod", x.CumProd(1), [][]float64{{1, 3, 6}, {-1, -4, 8}}},
+		{"cummin", x.CumMin(1), [][]float64{{1, 1, 1}, {-1, -1, -2}}},
+		{"cummax", x.CumMax(1), [][]float64{{1, 3, 3}, {-1, 4, 4}}},
+		{"view", x.Transpose().CumSum(0), [][]float64{{1, -1}, {4, 3}, {6, 1}}},
+		{"logcumsumexp", x.LogCumSumExp(1), [][]float64{
+			{1, math.Log(math.E + math.Exp(3)), math.Log(math.E + math.Exp(3) + math.Exp(2))},
+			{-1, math.Log(math.Exp(-1) + math.Exp(4)), math.Log(math.Exp(-1) + math.Exp(4) + math.Exp(-2))},
+		}},
+	}
+
+	for _, tt := range tests {
+		if want := From[float64](tt.want); !near(tt.got, want, 1e-12) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, want)
+		}
+	}
+
+	if _, err := x.TryScan(2, nil); !errors.Is(err, ErrAxisBounds) {
+		t.Errorf("got %v, want %v", err, ErrAxisBounds)
+	}
+}
+
+func TestScanSpecialValues(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	x := From[float64]([]float64{2, nan, 1, 3})
+
+	for name, got := range map[string][]float64{
+		"cummin": x.CumMin(0).Ravel(),
+		"cummax": x.CumMax(0).Ravel(),
+	} {
+		if got[0] != 2 || !math.IsNaN(got[1]) || !math.IsNaN(got[2]) || !math.IsNaN(got[3]) {
+			t.Errorf("%s: got %v, want NaNs from the second element", name, got)
+		}
+	}
+
+	big := From[float64]([]float64{1000, 1000, -inf, inf})
+	got := big.LogCumSumExp(0).Ravel()
+	if math.Abs(got[1]-(1000+math.Ln2)) > 1e-9 || got[2] != got[1] || got[3] != inf {
+		t.Errorf("logcumsumexp: got %v", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	x := From[int]([][]int{{1, 4, 9, 16}, {2, 3, 7, 7}})
+
+	tests := []struct {
+		name    string
+		n, axis int
+		want    *Tensor[int]
+	}{
+		{"none", 0, 1, x},
+		{"first", 1, 1, From[int]([][]int{{3, 5, 7}, {1, 4, 0}})},
+		{"second", 2, 1, From[int]([][]int{{2, 2}, {3, -4}})},
+		{"third", 3, 1, From[int]([][]int{{0}, {-7}})},
+		{"first axis", 1, 0, From[int]([][]int{{1, -1, -2, -9}})},
+	}
+
+	for _, tt := range tests {
+		if got := x.Diff(tt.n, tt.axis); !equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := x.TryDiff(4, 1); !errors.Is(err, ErrIndexBounds) {
+		t.Errorf("got %v, want %v", err, ErrIndexBounds)
+	}
+}
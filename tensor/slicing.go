@@ -0,0 +1,249 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// subKind is the kind of a Subscript.
+type subKind int
+
+const (
+	subIndex subKind = iota
+	subSlice
+	subNewAxis
+	subEllipsis
+)
+
+// A Subscript selects elements along the axes of a Tensor, as the
+// subscripts of NumPy's basic slicing do. Subscripts are created
+// with I, S or Step, or are one of NewAxis and Ellipsis.
+type Subscript struct {
+	kind             subKind
+	index            int // the index, for an index subscript
+	start, end, step int // the interval and step size, for a slice subscript
+	hasStart, hasEnd bool
+}
+
+var (
+	// NewAxis inserts a new axis of dimension 1, as None does in NumPy.
+	NewAxis = Subscript{kind: subNewAxis}
+
+	// Ellipsis selects all the elements of as many axes as needed
+	// for the other subscripts to cover the Tensor's axes.
+	Ellipsis = Subscript{kind: subEllipsis}
+)
+
+// I returns a Subscript selecting the given index of an axis, and
+// removing the axis. Negative indices count from the axis' end.
+func I(index int) Subscript {
+	return Subscript{kind: subIndex, index: index}
+}
+
+// S returns a Subscript selecting the interval [start, end) of an axis,
+// taking every step-th element, as start:end:step does in Python.
+// Both start and end are ints, or nil for the axis' bounds, and negative
+// ones count from the axis' end. The step defaults to 1, and a negative
+// step walks the axis backwards.
+func S(start, end any, step ...int) Subscript {
+	assertArgsBounds(len(step), 1)
+
+	s := Subscript{kind: subSlice, step: 1}
+	if len(step) == 1 {
+		s.step = step[0]
+	}
+
+	s.start, s.hasStart = sliceBound(start)
+	s.end, s.hasEnd = sliceBound(end)
+
+	return s
+}
+
+// Step returns a Subscript selecting every step-th element
+// of an axis, as ::step does in Python.
+func Step(step int) Subscript {
+	return S(nil, nil, step)
+}
+
+// sliceBound returns the value of a bound given to S,
+// and whether or not it isn't nil.
+func sliceBound(b any) (int, bool) {
+	switch b := b.(type) {
+	case nil:
+		return 0, false
+	case int:
+		return b, true
+	default:
+		panic(&SliceError{Expr: fmt.Sprint(b), Axis: -1, Err: ErrBadSlice})
+	}
+}
+
+// String returns the Subscript as written in Python.
+func (s Subscript) String() string {
+	switch s.kind {
+	case subIndex:
+		return strconv.Itoa(s.index)
+	case subNewAxis:
+		return "None"
+	case subEllipsis:
+		return "..."
+	}
+
+	var b strings.Builder
+	if s.hasStart {
+		b.WriteString(strconv.Itoa(s.start))
+	}
+	b.WriteString(":")
+	if s.hasEnd {
+		b.WriteString(strconv.Itoa(s.end))
+	}
+	if s.step != 1 {
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(s.step))
+	}
+
+	return b.String()
+}
+
+// At returns a view over the Tensor selected by the given slicing
+// expression, written as the subscripts between the brackets of NumPy's
+// basic slicing, such as "1:, ::2, -1, None, ...". Each subscript is an
+// index, a start:end:step slice whose parts are optional, None for
+// a new axis, or ... for an ellipsis, as described by Sl.
+func (t *Tensor[T]) At(expr string) *Tensor[T] {
+	subs, err := parseSubscripts(expr)
+	if err != nil {
+		err.Shape = t.Shape()
+		panic(err)
+	}
+
+	return t.Sl(subs...)
+}
+
+// Sl returns a view over the Tensor selected by the given Subscripts,
+// applied to its leading axes in order, the remaining axes being kept
+// whole. Index subscripts remove their axis, while an Ellipsis stands
+// for as many whole axes as needed to cover the Tensor's axes.
+// Indices must fall within their axis, while slices are clipped to it
+// as in Python, but must not be empty.
+func (t *Tensor[T]) Sl(subs ...Subscript) *Tensor[T] {
+	return t.view(t.layout.Subscripts(subs))
+}
+
+// parseSubscripts parses a slicing expression into Subscripts.
+func parseSubscripts(expr string) ([]Subscript, *SliceError) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(expr, ",")
+	subs := make([]Subscript, len(parts))
+
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		bad := &SliceError{Expr: part, Axis: -1, Err: ErrBadSlice}
+
+		switch {
+		case part == "None":
+			subs[i] = NewAxis
+		case part == "...":
+			subs[i] = Ellipsis
+		case strings.Contains(part, ":"):
+			bounds := strings.Split(part, ":")
+			if len(bounds) > 3 {
+				return nil, bad
+			}
+
+			vals := [3]any{}
+			for j, b := range bounds {
+				if b = strings.TrimSpace(b); b == "" {
+					continue
+				}
+
+				v, err := strconv.Atoi(b)
+				if err != nil {
+					return nil, bad
+				}
+				vals[j] = v
+			}
+
+			step := 1
+			if vals[2] != nil {
+				step = vals[2].(int)
+			}
+
+			subs[i] = S(vals[0], vals[1], step)
+		default:
+			v, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, bad
+			}
+
+			subs[i] = I(v)
+		}
+	}
+
+	return subs, nil
+}
+
+// indices returns the first index and the number of indices selected
+// by the slice Subscript along an axis of dimension n, following
+// Python's rules to clip the slice's bounds.
+func (s Subscript) indices(n int) (int, int) {
+	clip := func(x, lo, hi int) int {
+		if x < 0 {
+			x += n
+		}
+		if x < lo {
+			return lo
+		}
+		if x > hi {
+			return hi
+		}
+		return x
+	}
+
+	var start, end int
+	if s.step > 0 {
+		start, end = 0, n
+		if s.hasStart {
+			start = clip(s.start, 0, n)
+		}
+		if s.hasEnd {
+			end = clip(s.end, 0, n)
+		}
+
+		if end <= start {
+			return start, 0
+		}
+		return start, (end - start + s.step - 1) / s.step
+	}
+
+	start, end = n-1, -1
+	if s.hasStart {
+		start = clip(s.start, -1, n-1)
+	}
+	if s.hasEnd {
+		end = clip(s.end, -1, n-1)
+	}
+
+	if start <= end {
+		return start, 0
+	}
+	return start, (start - end - s.step - 1) / -s.step
+}
+
+// subscriptsString returns the Subscripts as written in Python.
+func subscriptsString(subs []Subscript) string {
+	parts := make([]string, len(subs))
+	for i, s := range subs {
+		parts[i] = s.String()
+	}
+
+	return strings.Join(parts, ", ")
+}
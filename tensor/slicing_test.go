@@ -0,0 +1,91 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAt(t *testing.T) {
+	x := Range[int](0, 12, 1).Reshape(3, 4)
+
+	tests := []struct {
+		expr string
+		subs []Subscript
+		want *Tensor[int]
+	}{
+		{"", nil, x},
+		{"1", []Subscript{I(1)}, FromBuffer([]int{4, 5, 6, 7}, 4)},
+		{"-1, -2", []Subscript{I(-1), I(-2)}, From[int](10)},
+		{"1:", []Subscript{S(1, nil)}, Range[int](4, 12, 1).Reshape(2, 4)},
+		{":, ::2", []Subscript{S(nil, nil), Step(2)}, FromBuffer([]int{0, 2, 4, 6, 8, 10}, 3, 2)},
+		{"::-1", []Subscript{Step(-1)}, FromBuffer([]int{8, 9, 10, 11, 4, 5, 6, 7, 0, 1, 2, 3}, 3, 4)},
+		{"0, 3:0:-2", []Subscript{I(0), S(3, 0, -2)}, FromBuffer([]int{3, 1}, 2)},
+		{"-1, ::-3", []Subscript{I(-1), Step(-3)}, FromBuffer([]int{11, 8}, 2)},
+		{":-1, -2:", []Subscript{S(nil, -1), S(-2, nil)}, FromBuffer([]int{2, 3, 6, 7}, 2, 2)},
+		{"-100:100", []Subscript{S(-100, 100)}, x},
+		{"None, 1", []Subscript{NewAxis, I(1)}, FromBuffer([]int{4, 5, 6, 7}, 1, 4)},
+		{"..., 1", []Subscript{Ellipsis, I(1)}, FromBuffer([]int{1, 5, 9}, 3)},
+		{"2, ..., None", []Subscript{I(2), Ellipsis, NewAxis}, FromBuffer([]int{8, 9, 10, 11}, 4, 1)},
+		{" 1 : 3 , 1 ", []Subscript{S(1, 3), I(1)}, FromBuffer([]int{5, 9}, 2)},
+	}
+
+	for _, tt := range tests {
+		if got := x.At(tt.expr); !equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.expr, got, tt.want)
+		}
+		if got := x.Sl(tt.subs...); !equal(got, tt.want) {
+			t.Errorf("%q: Sl: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	// the selected elements are views over the Tensor
+	x.At("::-1, 0").Assign([]int{-8, -4, 0})
+	if want := FromBuffer([]int{0, -4, -8}, 3); !equal(x.At(":, 0"), want) {
+		t.Errorf("assign: got %v, want %v", x.At(":, 0"), want)
+	}
+}
+
+func TestAtErrors(t *testing.T) {
+	x := Range[int](0, 6, 1).Reshape(2, 3)
+
+	tests := []struct {
+		expr string
+		err  error
+		msg  string
+	}{
+		{"2", ErrIndexBounds, `nune: index out of bounds: "2" at axis 0 of shape [2 3]`},
+		{"0, -4", ErrIndexBounds, `nune: index out of bounds: "-4" at axis 1 of shape [2 3]`},
+		{":, ::0", ErrBadStep, `nune: received a bad step size: "::0" at axis 1 of shape [2 3]`},
+		{"1:1", ErrBadInterval, `nune: received a bad interval: "1:1" at axis 0 of shape [2 3]`},
+		{":, 0:2:-1", ErrBadInterval, `nune: received a bad interval: "0:2:-1" at axis 1 of shape [2 3]`},
+		{"0, 0, 0", ErrBadSlice, `nune: received a bad slicing expression: "0, 0, 0" for shape [2 3]`},
+		{"..., ...", ErrBadSlice, `nune: received a bad slicing expression: "..." for shape [2 3]`},
+		{"1:2:3:4", ErrBadSlice, `nune: received a bad slicing expression: "1:2:3:4" for shape [2 3]`},
+		{"a", ErrBadSlice, `nune: received a bad slicing expression: "a" for shape [2 3]`},
+		{"0, 1.5", ErrBadSlice, `nune: received a bad slicing expression: "1.5" for shape [2 3]`},
+	}
+
+	for _, tt := range tests {
+		_, err := x.TryAt(tt.expr)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%q: got %v, want %v", tt.expr, err, tt.err)
+			continue
+		}
+
+		var se *SliceError
+		if !errors.As(err, &se) {
+			t.Errorf("%q: got %v, want a *SliceError", tt.expr, err)
+		} else if se.Error() != tt.msg {
+			t.Errorf("%q: got %q, want %q", tt.expr, se.Error(), tt.msg)
+		}
+	}
+
+	// S panics itself, before Sl is called
+	if _, err := try(func() Subscript { return S("1", nil) }); !errors.Is(err, ErrBadSlice) {
+		t.Errorf("bad bound: got %v, want %v", err, ErrBadSlice)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// Sort returns a copy of the Tensor whose elements are sorted along
+// the given axis, in ascending or descending order, in a new Tensor.
+// NaNs are ordered after every other element, as if they were the
+// greatest.
+func (t *Tensor[T]) Sort(axis int, descending bool) *Tensor[T] {
+	idx := t.ArgSort(axis, descending)
+
+	res := Zeros[T](t.layout.Shape()...)
+	cpd.Gather(t.layout.Shape(), axis, idx.span(), t.span(), res.span())
+
+	return res
+}
+
+// ArgSort returns the indices which sort the Tensor along the given
+// axis, in ascending or descending order, in a new Tensor of the
+// Tensor's shape. The sort is stable: equal elements keep their order.
+// NaNs are ordered after every other element, as if they were the
+// greatest.
+func (t *Tensor[T]) ArgSort(axis int, descending bool) *Tensor[int] {
+	assertAxisBounds(axis, t.Rank())
+
+	res := Zeros[int](t.layout.Shape()...)
+	cpd.ArgSort(t.layout.Shape(), axis, t.span(), res.span(), descending)
+
+	return res
+}
+
+// TopK returns the k largest elements of the Tensor along the given
+// axis, or the k smallest ones if largest is false, along with their
+// indices, in new Tensors whose dimension at that axis is k. The
+// elements are in sorted order, largest or smallest first.
+func (t *Tensor[T]) TopK(k, axis int, largest bool) (*Tensor[T], *Tensor[int]) {
+	assertAxisBounds(axis, t.Rank())
+	assertInRange(k, 1, t.Size(axis)+1)
+
+	idx := t.ArgSort(axis, largest).SliceAxis(axis, 0, k, 1).Copy()
+
+	res := Zeros[T](idx.layout.Shape()...)
+	cpd.Gather(idx.layout.Shape(), axis, idx.span(), t.span(), res.span())
+
+	return res, idx
+}
+
+// Kthvalue returns the k-th smallest elements of the Tensor along the
+// given axis, counting from 1, along with their indices, in new Tensors
+// without that axis, or whose dimension at that axis is 1 if keepDims
+// is true.
+func (t *Tensor[T]) Kthvalue(k, axis int, keepDims bool) (*Tensor[T], *Tensor[int]) {
+	assertAxisBounds(axis, t.Rank())
+	assertInRange(k, 1, t.Size(axis)+1)
+
+	idx := t.ArgSort(axis, false).SliceAxis(axis, k-1, k, 1).Copy()
+
+	res := Zeros[T](idx.layout.Shape()...)
+	cpd.Gather(idx.layout.Shape(), axis, idx.span(), t.span(), res.span())
+
+	if !keepDims {
+		return res.Squeeze(axis), idx.Squeeze(axis)
+	}
+
+	return res, idx
+}
+
+// Median returns the median of all elements in the Tensor, which is
+// the lower of the two middle elements for an even number of elements.
+func (t *Tensor[T]) Median() T {
+	res, _ := t.Reshape(-1).MedianAxis(0, false)
+
+	return res.Ravel()[0]
+}
+
+// MedianAxis returns the medians of the Tensor along the given axis,
+// along with their indices, as Kthvalue does. The median is the lower
+// of the two middle elements for an even dimension.
+func (t *Tensor[T]) MedianAxis(axis int, keepDims bool) (*Tensor[T], *Tensor[int]) {
+	assertAxisBounds(axis, t.Rank())
+
+	return t.Kthvalue((t.Size(axis)+1)/2, axis, keepDims)
+}
+
+// Unique returns the unique elements of the Tensor, in ascending order,
+// in a new rank 1 Tensor. If returnCounts is true, it also returns the
+// number of occurrences of each unique element in a Tensor of the same
+// shape, and if returnInverse is true, the index of each of the Tensor's
+// elements among the unique ones, in a Tensor of the Tensor's shape.
+// Those which aren't requested are nil. NaNs are ordered last, and
+// count as a single unique element.
+func (t *Tensor[T]) Unique(returnCounts, returnInverse bool) (values *Tensor[T], counts, inverse *Tensor[int]) {
+	data := t.flat()
+	n := len(data)
+
+	perm := slice.WithLen[int](n)
+	cpd.ArgSort([]int{n}, 0, cpd.Flat(data, []int{n}), cpd.Flat(perm, []int{n}), false)
+
+	var uniq []T
+	var cnt []int
+	inv := slice.WithLen[int](n)
+
+	for _, i := range perm {
+		if len(uniq) == 0 || !same(uniq[len(uniq)-1], data[i]) {
+			uniq = append(uniq, data[i])
+			cnt = append(cnt, 0)
+		}
+
+		cnt[len(cnt)-1]++
+		inv[i] = len(uniq) - 1
+	}
+
+	values = FromBuffer(uniq, len(uniq))
+	if returnCounts {
+		counts = FromBuffer(cnt, len(cnt))
+	}
+	if returnInverse {
+		inverse = FromBuffer(inv, t.Shape()...)
+	}
+
+	return values, counts, inverse
+}
+
+// same returns whether or not x and y are equal, or are both NaNs.
+func same[T nune.Numeric](x, y T) bool {
+	return x == y || x != x && y != y
+}
+
+// SearchSorted returns the indices at which the elements of values
+// would be inserted into the sorted Tensor, along its last axis, to
+// keep it sorted, in a new Tensor of the values' shape. An index is the
+// one before the elements equal to the inserted one, or after them if
+// right is true.
+//
+// The sorted Tensor is either of rank 1, and searched for all the values,
+// or has the values' dimensions along all but its last axis, and each
+// of its lines is searched for the values on the matching line.
+func SearchSorted[T nune.Numeric](sorted, values *Tensor[T], right bool) *Tensor[int] {
+	assertAxisBounds(0, sorted.Rank())
+	if values.Rank() == 0 {
+		return SearchSorted(sorted, values.Reshape(1), right).Reshape()
+	}
+
+	m := sorted.Size(sorted.Rank() - 1)
+	shape := values.layout.Shape()
+
+	seq := sorted.span()
+	switch {
+	case sorted.Rank() == 1:
+		seq.Strides = slice.WithLen[int](len(shape))
+		seq.Strides[len(shape)-1] = sorted.layout.Strides()[0]
+	case sorted.Rank() != len(shape) || !slice.Equal(sorted.layout.Shape()[:len(shape)-1], shape[:len(shape)-1]):
+		expected := append(slice.Copy(shape[:len(shape)-1]), m)
+		panic(shapeError("SearchSorted", expected, sorted.layout.Shape(), ErrShapeMismatch))
+	}
+
+	res := Zeros[int](shape...)
+	cpd.SearchSorted(shape, seq, m, values.span(), res.span(), right)
+
+	return res
+}
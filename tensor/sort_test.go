@@ -0,0 +1,155 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	nan := math.NaN()
+	x := From[float64]([][]float64{
+		{3, nan, 1, 2, 1},
+		{0, -1, 5, -1, 4},
+	})
+
+	tests := []struct {
+		name       string
+		axis       int
+		descending bool
+		values     [][]float64
+		indices    [][]int
+	}{
+		{"last axis", 1, false, [][]float64{{1, 1, 2, 3, nan}, {-1, -1, 0, 4, 5}}, [][]int{{2, 4, 3, 0, 1}, {1, 3, 0, 4, 2}}},
+		{"descending", 1, true, [][]float64{{nan, 3, 2, 1, 1}, {5, 4, 0, -1, -1}}, [][]int{{1, 0, 3, 2, 4}, {2, 4, 0, 1, 3}}},
+		{"first axis", 0, false, [][]float64{{0, -1, 1, -1, 1}, {3, nan, 5, 2, 4}}, [][]int{{1, 1, 0, 1, 0}, {0, 0, 1, 0, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := x.ArgSort(tt.axis, tt.descending), From[int](tt.indices); !equal(got, want) {
+				t.Errorf("ArgSort: got %v, want %v", got, want)
+			}
+
+			got := x.Sort(tt.axis, tt.descending).Ravel()
+			want := From[float64](tt.values).Ravel()
+			for i := range got {
+				if !same(got[i], want[i]) {
+					t.Fatalf("Sort: got %v, want %v", got, want)
+				}
+			}
+		})
+	}
+
+	// sorting a non-contiguous view
+	v := Range[int](0, 12, 1).Reshape(3, 4).Transpose()
+	if got, want := v.Sort(1, true), From[int]([][]int{{8, 4, 0}, {9, 5, 1}, {10, 6, 2}, {11, 7, 3}}); !equal(got, want) {
+		t.Errorf("view: got %v, want %v", got, want)
+	}
+
+	if _, err := x.TrySort(2, false); !errors.Is(err, ErrAxisBounds) {
+		t.Errorf("got %v, want %v", err, ErrAxisBounds)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	x := From[int]([][]int{{4, 9, 1, 7}, {2, 2, 8, 0}})
+
+	tests := []struct {
+		name    string
+		k, axis int
+		largest bool
+		values  [][]int
+		indices [][]int
+	}{
+		{"largest", 2, 1, true, [][]int{{9, 7}, {8, 2}}, [][]int{{1, 3}, {2, 0}}},
+		{"smallest", 3, 1, false, [][]int{{1, 4, 7}, {0, 2, 2}}, [][]int{{2, 0, 3}, {3, 0, 1}}},
+		{"first axis", 1, 0, true, [][]int{{4, 9, 8, 7}}, [][]int{{0, 0, 1, 0}}},
+	}
+
+	for _, tt := range tests {
+		values, indices := x.TopK(tt.k, tt.axis, tt.largest)
+		if !equal(values, From[int](tt.values)) || !equal(indices, From[int](tt.indices)) {
+			t.Errorf("%s: got %v and %v, want %v and %v", tt.name, values, indices, tt.values, tt.indices)
+		}
+	}
+
+	if _, _, err := x.TryTopK(5, 1, true); !errors.Is(err, ErrIndexBounds) {
+		t.Errorf("got %v, want %v", err, ErrIndexBounds)
+	}
+}
+
+func TestKthvalueAndMedian(t *testing.T) {
+	x := From[int]([][]int{{5, 1, 4, 2}, {3, 3, 9, 0}})
+
+	values, indices := x.Kthvalue(2, 1, false)
+	if !equal(values, From[int]([]int{2, 3})) || !equal(indices, From[int]([]int{3, 0})) {
+		t.Errorf("Kthvalue: got %v and %v", values, indices)
+	}
+
+	values, indices = x.MedianAxis(0, true)
+	if !equal(values, From[int]([][]int{{3, 1, 4, 0}})) || !equal(indices, From[int]([][]int{{1, 0, 0, 1}})) {
+		t.Errorf("MedianAxis: got %v and %v", values, indices)
+	}
+
+	if got := x.Median(); got != 3 {
+		t.Errorf("Median: got %v, want 3", got)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	nan := math.NaN()
+	x := From[float64]([][]float64{{2, nan, 1}, {2, nan, -1}})
+
+	values, counts, inverse := x.Unique(true, true)
+
+	got := values.Ravel()
+	want := []float64{-1, 1, 2, nan}
+	for i := range want {
+		if len(got) != len(want) || !same(got[i], want[i]) {
+			t.Fatalf("values: got %v, want %v", got, want)
+		}
+	}
+
+	if !reflect.DeepEqual(counts.Ravel(), []int{1, 1, 2, 2}) {
+		t.Errorf("counts: got %v, want [1 1 2 2]", counts)
+	}
+	if want := From[int]([][]int{{2, 3, 1}, {2, 3, 0}}); !equal(inverse, want) {
+		t.Errorf("inverse: got %v, want %v", inverse, want)
+	}
+
+	if _, counts, inverse := x.Unique(false, false); counts != nil || inverse != nil {
+		t.Error("got counts or inverse without requesting them")
+	}
+}
+
+func TestSearchSorted(t *testing.T) {
+	sorted := From[int]([]int{1, 3, 3, 5})
+	values := From[int]([][]int{{0, 3}, {4, 6}})
+
+	if got, want := SearchSorted(sorted, values, false), From[int]([][]int{{0, 1}, {3, 4}}); !equal(got, want) {
+		t.Errorf("left: got %v, want %v", got, want)
+	}
+	if got, want := SearchSorted(sorted, values, true), From[int]([][]int{{0, 3}, {3, 4}}); !equal(got, want) {
+		t.Errorf("right: got %v, want %v", got, want)
+	}
+
+	// one sorted line per line of values
+	lines := From[int]([][]int{{1, 2, 3}, {10, 20, 30}})
+	if got, want := SearchSorted(lines, From[int]([][]int{{2}, {25}}), false), From[int]([][]int{{1}, {2}}); !equal(got, want) {
+		t.Errorf("lines: got %v, want %v", got, want)
+	}
+
+	if got := SearchSorted(sorted, From[int](4), false); got.Rank() != 0 || got.Ravel()[0] != 3 {
+		t.Errorf("scalar: got %v, want 3", got)
+	}
+
+	if _, err := TrySearchSorted(lines, From[int]([]int{1, 2}), false); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("got %v, want %v", err, ErrShapeMismatch)
+	}
+}
@@ -7,6 +7,7 @@ package tensor
 import (
 	"github.com/lordlarker/nune"
 	"github.com/lordlarker/nune/internal/cpd"
+	"github.com/lordlarker/nune/internal/slice"
 )
 
 // A Tensor is a generic, n-dimensional numerical type.
@@ -18,3 +19,55 @@ type Tensor[T nune.Numeric] struct {
 func newStorage[T nune.Numeric](data []T) *cpd.Storage[T] {
 	return cpd.NewStorage(data)
 }
+
+// span returns the strided span through which
+// the Tensor walks its storage.
+func (t *Tensor[T]) span() cpd.Span[T] {
+	return cpd.Span[T]{
+		Buf:     t.storage.Load(),
+		Strides: t.layout.Strides(),
+		Offset:  t.layout.Offset(),
+	}
+}
+
+// view returns a Tensor sharing the Tensor's storage
+// through the given layout.
+func (t *Tensor[T]) view(l *layout) *Tensor[T] {
+	return &Tensor[T]{
+		storage: t.storage,
+		layout:  l,
+	}
+}
+
+// flat returns the Tensor's elements in row-major order,
+// avoiding a copy when the Tensor is contiguous.
+// The returned buffer must be treated as read-only.
+func (t *Tensor[T]) flat() []T {
+	if t.layout.Contiguous() {
+		return t.storage.Slice(t.layout.Offset(), t.layout.Offset()+t.layout.Numel())
+	}
+
+	return t.Ravel()
+}
+
+// result returns the Tensor into which an operation whose results
+// have the given shape writes: either the given out Tensor,
+// or a new one if it isn't provided. The op names the operation
+// in the error reported if out doesn't have the given shape.
+func result[T nune.Numeric](op string, shape []int, out []*Tensor[T]) *Tensor[T] {
+	assertArgsBounds(len(out), 1)
+
+	if len(out) == 1 {
+		assertWritable(out[0])
+		if !slice.Equal(out[0].layout.Shape(), shape) {
+			panic(shapeError(op, shape, out[0].layout.Shape(), ErrBadShape))
+		}
+
+		return out[0]
+	}
+
+	return &Tensor[T]{
+		storage: newStorage(slice.WithLen[T](slice.Prod(shape))),
+		layout:  newLayout(slice.Copy(shape)),
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import "github.com/lordlarker/nune"
+
+// TryFrom is like From, but returns an error
+// instead of panicking if the backing is invalid.
+func TryFrom[T nune.Numeric](b any) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return From[T](b)
+	})
+}
+
+// TryFromBuffer is like FromBuffer, but returns an error instead
+// of panicking if the buffer doesn't match the given shape.
+func TryFromBuffer[T nune.Numeric](data []T, shape ...int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return FromBuffer(data, shape...)
+	})
+}
+
+// TryBroadcastShapes is like BroadcastShapes, but returns an error
+// instead of panicking if the shapes cannot be broadcasted together.
+func TryBroadcastShapes(a, b []int) ([]int, error) {
+	return try(func() []int {
+		return BroadcastShapes(a, b)
+	})
+}
+
+// TryAdd is like Add, but returns an error instead of panicking.
+func TryAdd[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Add(a, b, out...)
+	})
+}
+
+// TrySub is like Sub, but returns an error instead of panicking.
+func TrySub[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Sub(a, b, out...)
+	})
+}
+
+// TryMul is like Mul, but returns an error instead of panicking.
+func TryMul[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Mul(a, b, out...)
+	})
+}
+
+// TryDiv is like Div, but returns an error instead of panicking.
+func TryDiv[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Div(a, b, out...)
+	})
+}
+
+// TryMatMul is like MatMul, but returns an error instead of panicking.
+func TryMatMul[T nune.Numeric](a, b *Tensor[T], out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return MatMul(a, b, out...)
+	})
+}
+
+// TryEinsum is like Einsum, but returns an error instead of panicking.
+func TryEinsum[T nune.Numeric](subscripts string, operands ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Einsum(subscripts, operands...)
+	})
+}
+
+// TryWhere is like Where, but returns an error instead of panicking.
+func TryWhere[T nune.Numeric](mask *Mask, a, b *Tensor[T], out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Where(mask, a, b, out...)
+	})
+}
+
+// TryConvolve is like Convolve, but returns an error instead of panicking.
+func TryConvolve[T nune.Numeric](a, v *Tensor[T], mode ConvMode, out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Convolve(a, v, mode, out...)
+	})
+}
+
+// TryCorrelate is like Correlate, but returns an error instead of panicking.
+func TryCorrelate[T nune.Numeric](a, v *Tensor[T], mode ConvMode, out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Correlate(a, v, mode, out...)
+	})
+}
+
+// TryConv1d is like Conv1d, but returns an error instead of panicking.
+func TryConv1d[T nune.Numeric](x, w *Tensor[T], opts ConvOptions, out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Conv1d(x, w, opts, out...)
+	})
+}
+
+// TryConv2d is like Conv2d, but returns an error instead of panicking.
+func TryConv2d[T nune.Numeric](x, w *Tensor[T], opts ConvOptions, out ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Conv2d(x, w, opts, out...)
+	})
+}
+
+// TryAssign is like Assign, but returns an error instead of panicking.
+func (t *Tensor[T]) TryAssign(v any) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Assign(v)
+	})
+}
+
+// TryReshape is like Reshape, but returns an error instead of panicking.
+func (t *Tensor[T]) TryReshape(s ...int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Reshape(s...)
+	})
+}
+
+// TryIndex is like Index, but returns an error instead of panicking.
+func (t *Tensor[T]) TryIndex(indices ...int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Index(indices...)
+	})
+}
+
+// TrySlice is like Slice, but returns an error instead of panicking.
+func (t *Tensor[T]) TrySlice(start, end int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Slice(start, end)
+	})
+}
+
+// TrySliceAxis is like SliceAxis, but returns an error instead of panicking.
+func (t *Tensor[T]) TrySliceAxis(axis, start, end, step int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.SliceAxis(axis, start, end, step)
+	})
+}
+
+// TryBroadcastTo is like BroadcastTo, but returns an error instead of panicking.
+func (t *Tensor[T]) TryBroadcastTo(shape ...int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.BroadcastTo(shape...)
+	})
+}
+
+// TryPermute is like Permute, but returns an error instead of panicking.
+func (t *Tensor[T]) TryPermute(axes ...int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Permute(axes...)
+	})
+}
+
+// TryMoveAxis is like MoveAxis, but returns an error instead of panicking.
+func (t *Tensor[T]) TryMoveAxis(src, dst int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.MoveAxis(src, dst)
+	})
+}
+
+// TrySwapAxes is like SwapAxes, but returns an error instead of panicking.
+func (t *Tensor[T]) TrySwapAxes(a, b int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.SwapAxes(a, b)
+	})
+}
+
+// TryFlatten is like Flatten, but returns an error instead of panicking.
+func (t *Tensor[T]) TryFlatten(start, end int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Flatten(start, end)
+	})
+}
+
+// TrySqueeze is like Squeeze, but returns an error instead of panicking.
+func (t *Tensor[T]) TrySqueeze(axes ...int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Squeeze(axes...)
+	})
+}
+
+// TryUnsqueeze is like Unsqueeze, but returns an error instead of panicking.
+func (t *Tensor[T]) TryUnsqueeze(axis int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Unsqueeze(axis)
+	})
+}
+
+// TryExpand is like Expand, but returns an error instead of panicking.
+func (t *Tensor[T]) TryExpand(shape ...int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Expand(shape...)
+	})
+}
+
+// TryTake is like Take, but returns an error instead of panicking.
+func (t *Tensor[T]) TryTake(indices *Tensor[int]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Take(indices)
+	})
+}
+
+// TryIndexSelect is like IndexSelect, but returns an error instead of panicking.
+func (t *Tensor[T]) TryIndexSelect(axis int, indices *Tensor[int]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.IndexSelect(axis, indices)
+	})
+}
+
+// TryGather is like Gather, but returns an error instead of panicking.
+func (t *Tensor[T]) TryGather(axis int, indices *Tensor[int]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Gather(axis, indices)
+	})
+}
+
+// TryScatter is like Scatter, but returns an error instead of panicking.
+func (t *Tensor[T]) TryScatter(axis int, indices *Tensor[int], src *Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Scatter(axis, indices, src)
+	})
+}
+
+// TryScatterAdd is like ScatterAdd, but returns an error instead of panicking.
+func (t *Tensor[T]) TryScatterAdd(axis int, indices *Tensor[int], src *Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.ScatterAdd(axis, indices, src)
+	})
+}
+
+// TryAt is like At, but returns an error instead of panicking.
+func (t *Tensor[T]) TryAt(expr string) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.At(expr)
+	})
+}
+
+// TrySl is like Sl, but returns an error instead of panicking.
+func (t *Tensor[T]) TrySl(subs ...Subscript) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Sl(subs...)
+	})
+}
+
+// TryConcat is like Concat, but returns an error instead of panicking.
+func TryConcat[T nune.Numeric](axis int, ts ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Concat(axis, ts...)
+	})
+}
+
+// TryStack is like Stack, but returns an error instead of panicking.
+func TryStack[T nune.Numeric](axis int, ts ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return Stack(axis, ts...)
+	})
+}
+
+// TryHStack is like HStack, but returns an error instead of panicking.
+func TryHStack[T nune.Numeric](ts ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return HStack(ts...)
+	})
+}
+
+// TryVStack is like VStack, but returns an error instead of panicking.
+func TryVStack[T nune.Numeric](ts ...*Tensor[T]) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return VStack(ts...)
+	})
+}
+
+// TrySplit is like Split, but returns an error instead of panicking.
+func (t *Tensor[T]) TrySplit(axis int, sizes ...int) ([]*Tensor[T], error) {
+	return try(func() []*Tensor[T] {
+		return t.Split(axis, sizes...)
+	})
+}
+
+// TryChunk is like Chunk, but returns an error instead of panicking.
+func (t *Tensor[T]) TryChunk(n, axis int) ([]*Tensor[T], error) {
+	return try(func() []*Tensor[T] {
+		return t.Chunk(n, axis)
+	})
+}
+
+// TryUnbind is like Unbind, but returns an error instead of panicking.
+func (t *Tensor[T]) TryUnbind(axis int) ([]*Tensor[T], error) {
+	return try(func() []*Tensor[T] {
+		return t.Unbind(axis)
+	})
+}
+
+// TrySort is like Sort, but returns an error instead of panicking.
+func (t *Tensor[T]) TrySort(axis int, descending bool) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Sort(axis, descending)
+	})
+}
+
+// TryArgSort is like ArgSort, but returns an error instead of panicking.
+func (t *Tensor[T]) TryArgSort(axis int, descending bool) (*Tensor[int], error) {
+	return try(func() *Tensor[int] {
+		return t.ArgSort(axis, descending)
+	})
+}
+
+// TryTopK is like TopK, but returns an error instead of panicking.
+func (t *Tensor[T]) TryTopK(k, axis int, largest bool) (values *Tensor[T], indices *Tensor[int], err error) {
+	_, err = try(func() any {
+		values, indices = t.TopK(k, axis, largest)
+		return nil
+	})
+
+	return values, indices, err
+}
+
+// TryKthvalue is like Kthvalue, but returns an error instead of panicking.
+func (t *Tensor[T]) TryKthvalue(k, axis int, keepDims bool) (values *Tensor[T], indices *Tensor[int], err error) {
+	_, err = try(func() any {
+		values, indices = t.Kthvalue(k, axis, keepDims)
+		return nil
+	})
+
+	return values, indices, err
+}
+
+// TryMedianAxis is like MedianAxis, but returns an error instead of panicking.
+func (t *Tensor[T]) TryMedianAxis(axis int, keepDims bool) (values *Tensor[T], indices *Tensor[int], err error) {
+	_, err = try(func() any {
+		values, indices = t.MedianAxis(axis, keepDims)
+		return nil
+	})
+
+	return values, indices, err
+}
+
+// TrySearchSorted is like SearchSorted, but returns an error instead of panicking.
+func TrySearchSorted[T nune.Numeric](sorted, values *Tensor[T], right bool) (*Tensor[int], error) {
+	return try(func() *Tensor[int] {
+		return SearchSorted(sorted, values, right)
+	})
+}
+
+// TryScan is like Scan, but returns an error instead of panicking.
+func (t *Tensor[T]) TryScan(axis int, f func(T, T) T) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Scan(axis, f)
+	})
+}
+
+// TryDiff is like Diff, but returns an error instead of panicking.
+func (t *Tensor[T]) TryDiff(n, axis int) (*Tensor[T], error) {
+	return try(func() *Tensor[T] {
+		return t.Diff(n, axis)
+	})
+}
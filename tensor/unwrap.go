@@ -18,7 +18,7 @@ import (
 // TODO: optimize the hell out of this
 func unwrapAny[T nune.Numeric](s []any, shape []int) ([]T, []int) {
 	if len(s) == 0 {
-		panic(errUnwrapBacking)
+		panic(ErrUnwrapBacking)
 	}
 
 	if anyIsNumeric(s[0]) {
@@ -29,8 +29,9 @@ func unwrapAny[T nune.Numeric](s []any, shape []int) ([]T, []int) {
 		d := reflect.ValueOf(s[0]).Len()
 
 		for i := 1; i < len(s); i++ {
-			if reflect.ValueOf(s[i]).Len() != d {
-				panic(errUnwrapBacking)
+			r := reflect.ValueOf(s[i])
+			if k := r.Kind(); k != reflect.Array && k != reflect.Slice || r.Len() != d {
+				panic(ErrUnwrapBacking)
 			}
 		}
 
@@ -42,12 +43,12 @@ func unwrapAny[T nune.Numeric](s []any, shape []int) ([]T, []int) {
 			}
 		}
 
-		shape = append(shape, len(s)*d)
+		shape = append(shape, d)
 
 		return unwrapAny[T](p, shape)
 	}
 
-	panic(errUnwrapBacking)
+	panic(ErrUnwrapBacking)
 }
 
 // anyIsNumeric returns whether or not an interface
@@ -99,7 +100,12 @@ func anyToNumeric[T nune.Numeric](s ...any) []T {
 func numericToNumeric[T, U nune.Numeric](s []any) []T {
 	ns := slice.WithLen[T](len(s))
 	for i := 0; i < len(s); i++ {
-		ns[i] = T(s[i].(U))
+		x, ok := s[i].(U)
+		if !ok {
+			panic(ErrUnwrapBacking)
+		}
+
+		ns[i] = T(x)
 	}
 
 	return ns
@@ -108,31 +114,31 @@ func numericToNumeric[T, U nune.Numeric](s []any) []T {
 // anyToTensor attempts to cast an interface{}
 // to a Tensor of the given numeric type.
 func anyToTensor[T nune.Numeric](a any) (*Tensor[T], bool) {
-	switch a.(type) {
-	case Tensor[int]:
-		return Cast[T](a.(*Tensor[int])), true
-	case Tensor[int8]:
-		return Cast[T](a.(*Tensor[int8])), true
-	case Tensor[int16]:
-		return Cast[T](a.(*Tensor[int16])), true
-	case Tensor[int32]:
-		return Cast[T](a.(*Tensor[int32])), true
-	case Tensor[int64]:
-		return Cast[T](a.(*Tensor[int64])), true
-	case Tensor[uint]:
-		return Cast[T](a.(*Tensor[uint])), true
-	case Tensor[uint8]:
-		return Cast[T](a.(*Tensor[uint8])), true
-	case Tensor[uint16]:
-		return Cast[T](a.(*Tensor[uint16])), true
-	case Tensor[uint32]:
-		return Cast[T](a.(*Tensor[uint32])), true
-	case Tensor[uint64]:
-		return Cast[T](a.(*Tensor[uint64])), true
-	case Tensor[float32]:
-		return Cast[T](a.(*Tensor[float32])), true
-	case Tensor[float64]:
-		return Cast[T](a.(*Tensor[float64])), true
+	switch t := a.(type) {
+	case *Tensor[int]:
+		return Cast[T](t), true
+	case *Tensor[int8]:
+		return Cast[T](t), true
+	case *Tensor[int16]:
+		return Cast[T](t), true
+	case *Tensor[int32]:
+		return Cast[T](t), true
+	case *Tensor[int64]:
+		return Cast[T](t), true
+	case *Tensor[uint]:
+		return Cast[T](t), true
+	case *Tensor[uint8]:
+		return Cast[T](t), true
+	case *Tensor[uint16]:
+		return Cast[T](t), true
+	case *Tensor[uint32]:
+		return Cast[T](t), true
+	case *Tensor[uint64]:
+		return Cast[T](t), true
+	case *Tensor[float32]:
+		return Cast[T](t), true
+	case *Tensor[float64]:
+		return Cast[T](t), true
 	default:
 		return &Tensor[T]{}, false
 	}
@@ -0,0 +1,35 @@
+// Copyright © Larker. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tensor
+
+import (
+	"math"
+
+	"github.com/lordlarker/nune"
+	"github.com/lordlarker/nune/internal/slice"
+)
+
+// equal returns whether or not the two Tensors have
+// the same shape and the same elements.
+func equal[T nune.Numeric](a, b *Tensor[T]) bool {
+	return near(a, b, 0)
+}
+
+// near returns whether or not the two Tensors have the same shape,
+// and elements which differ by no more than tol.
+func near[T nune.Numeric](a, b *Tensor[T], tol float64) bool {
+	if a == nil || b == nil || !slice.Equal(a.Shape(), b.Shape()) {
+		return false
+	}
+
+	x, y := a.Ravel(), b.Ravel()
+	for i := range x {
+		if math.Abs(float64(x[i])-float64(y[i])) > tol {
+			return false
+		}
+	}
+
+	return true
+}